@@ -3,24 +3,41 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"time"
 
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/artwork"
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/config"
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/player"
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-	if err := checkDependencies(); err != nil {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("Error: could not load the configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	mediaPlayer, err := player.New(cfg.Player, cfg.PlayerCommand)
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	cfg := config.Load()
-	client := jellyfin.NewClient(cfg.ServerURL)
+	images, err := artwork.Detect(cfg.Images)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	client := jellyfin.NewClient(cfg.ServerURL, cfg.DeviceID)
+	if cfg.RequestTimeout > 0 {
+		client.HTTPClient.Timeout = time.Duration(cfg.RequestTimeout) * time.Second
+	}
 
-	m := ui.NewModel(client, cfg)
+	m := ui.NewModel(client, cfg, mediaPlayer, artwork.NewStore(client, images))
 	p := tea.NewProgram(m)
 
 	if err := p.Start(); err != nil {
@@ -28,12 +45,3 @@ func main() {
 		os.Exit(1)
 	}
 }
-
-func checkDependencies() error {
-	// Check if MPV is installed
-	_, err := exec.LookPath("mpv")
-	if err != nil {
-		return fmt.Errorf("MPV is not installed or not in PATH. Please install MPV to use this application")
-	}
-	return nil
-}
@@ -0,0 +1,133 @@
+// Package artwork fetches item images from the server, caches them on disk
+// and renders them inline in the terminal: through the Kitty graphics
+// protocol or Sixel where the terminal supports them, and as Unicode
+// half-block art everywhere else.
+package artwork
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strings"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+)
+
+// Protocol is a way of drawing images in the terminal.
+type Protocol string
+
+const (
+	Kitty  Protocol = "kitty"
+	Sixel  Protocol = "sixel"
+	Blocks Protocol = "blocks"
+	None   Protocol = "none"
+)
+
+// Terminals don't tell us their cell size without a round trip the UI can't
+// make, so images are sized for the common 10x20 pixel cell. Kitty scales
+// to the cells we ask for regardless.
+const (
+	cellWidth  = 10
+	cellHeight = 20
+)
+
+// Detect resolves the images setting: "kitty", "sixel", "blocks" or "none"
+// choose a protocol, and "auto" or "" guess from the environment.
+func Detect(setting string) (Protocol, error) {
+	switch Protocol(setting) {
+	case Kitty, Sixel, Blocks, None:
+		return Protocol(setting), nil
+	case "", "auto":
+		return detect(), nil
+	default:
+		return None, fmt.Errorf("unknown images setting %q; use auto, kitty, sixel, blocks or none", setting)
+	}
+}
+
+func detect() Protocol {
+	term := os.Getenv("TERM")
+	program := os.Getenv("TERM_PROGRAM")
+
+	switch {
+	case term == "dumb":
+		return None
+	case os.Getenv("TMUX") != "":
+		// tmux swallows graphics escapes unless passthrough is configured.
+		return Blocks
+	case os.Getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty", term == "xterm-ghostty",
+		program == "WezTerm", program == "ghostty":
+		return Kitty
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"), strings.HasPrefix(term, "contour"),
+		program == "iTerm.app":
+		return Sixel
+	default:
+		return Blocks
+	}
+}
+
+// Store loads and renders item artwork.
+type Store struct {
+	client   *jellyfin.Client
+	protocol Protocol
+	// cache is nil when the cache directory is unusable; images are then
+	// fetched every time.
+	cache *Cache
+}
+
+func NewStore(client *jellyfin.Client, protocol Protocol) *Store {
+	cache, err := OpenCache()
+	if err != nil {
+		cache = nil
+	}
+	return &Store{
+		client:   client,
+		protocol: protocol,
+		cache:    cache,
+	}
+}
+
+func (s *Store) Enabled() bool {
+	return s.protocol != None
+}
+
+// Poster renders the item's primary image to fit within cols by rows
+// cells. Items without one render as "".
+func (s *Store) Poster(item jellyfin.MediaItem, cols, rows int) (string, error) {
+	tag := item.ImageTag(jellyfin.ImagePrimary)
+	if !s.Enabled() || tag == "" {
+		return "", nil
+	}
+
+	width, height := cols*cellWidth, rows*cellHeight
+	key := fmt.Sprintf("%s-%s-%dx%d", item.ID, tag, width, height)
+
+	data, ok := s.cache.Get(key)
+	if !ok {
+		var err error
+		data, err = s.client.GetImage(item.ID, jellyfin.ImagePrimary, width, height)
+		if err != nil {
+			return "", err
+		}
+		s.cache.Put(key, data)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("could not decode artwork for %s: %v", item.Name, err)
+	}
+
+	return Render(img, cols, rows, s.protocol), nil
+}
+
+// Clear removes images drawn outside the text layer. Kitty keeps images on
+// screen until told otherwise, so views without artwork must send this;
+// for the other protocols it is empty.
+func (s *Store) Clear() string {
+	if s.protocol == Kitty {
+		return kittyDeleteAll
+	}
+	return ""
+}
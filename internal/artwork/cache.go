@@ -0,0 +1,104 @@
+package artwork
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// maxCacheBytes bounds the disk cache; the oldest images go first.
+const maxCacheBytes = 100 << 20
+
+// Cache keeps downloaded images on disk, one file per key, so artwork
+// appears instantly on later visits and launches.
+type Cache struct {
+	dir string
+}
+
+// OpenCache opens the cache in the user's cache directory, trimming it to
+// maxCacheBytes.
+func OpenCache() (*Cache, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(base, "jellyfin-tui", "images")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	cache := &Cache{dir: dir}
+	cache.prune(maxCacheBytes)
+	return cache, nil
+}
+
+// Get returns the cached data for key. A nil Cache holds nothing.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores data under key. The file is written under a temporary name and
+// renamed, so a concurrent Get never sees half an image.
+func (c *Cache) Put(key string, data []byte) error {
+	if c == nil {
+		return nil
+	}
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, filepath.Base(key)+".jpg")
+}
+
+func (c *Cache) prune(maxBytes int64) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	var files []os.FileInfo
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil {
+			files = append(files, info)
+		}
+	}
+
+	var total int64
+	for _, file := range files {
+		total += file.Size()
+	}
+	if total <= maxBytes {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, file := range files {
+		if total <= maxBytes {
+			break
+		}
+		if os.Remove(filepath.Join(c.dir, file.Name())) == nil {
+			total -= file.Size()
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package artwork
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+)
+
+// kittyChunkSize is the largest payload the Kitty graphics protocol accepts
+// in one escape sequence.
+const kittyChunkSize = 4096
+
+// kittyDeleteAll removes every image placement on screen.
+const kittyDeleteAll = "\x1b_Ga=d,q=2\x1b\\"
+
+// kitty transmits img as PNG and places it over cols by rows cells at the
+// cursor. q=2 silences the terminal's replies, which would otherwise arrive
+// as keystrokes, and C=1 leaves the cursor where it was. Earlier images are
+// deleted first so redraws don't stack.
+func kitty(img image.Image, cols, rows int) string {
+	var data bytes.Buffer
+	if err := png.Encode(&data, img); err != nil {
+		return ""
+	}
+	payload := base64.StdEncoding.EncodeToString(data.Bytes())
+
+	var b strings.Builder
+	b.WriteString(kittyDeleteAll)
+	for first := true; first || payload != ""; first = false {
+		chunk := payload
+		if len(chunk) > kittyChunkSize {
+			chunk = chunk[:kittyChunkSize]
+		}
+		payload = payload[len(chunk):]
+
+		more := 0
+		if payload != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&b, "\x1b_Ga=T,f=100,q=2,C=1,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return b.String()
+}
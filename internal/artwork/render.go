@@ -0,0 +1,114 @@
+package artwork
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Render draws img as large as fits within cols by rows cells, keeping its
+// aspect ratio. The result is a block of lines as wide as the image, so it
+// can be laid out like text; Kitty and Sixel images are drawn from its first
+// line over blank cells.
+func Render(img image.Image, cols, rows int, protocol Protocol) string {
+	cols, rows = fit(img.Bounds(), cols, rows)
+	if cols == 0 || rows == 0 {
+		return ""
+	}
+
+	switch protocol {
+	case Kitty:
+		return overlay(kitty(scale(img, cols*cellWidth, rows*cellHeight), cols, rows), cols, rows)
+	case Sixel:
+		return overlay(sixel(scale(img, cols*cellWidth, rows*cellHeight)), cols, rows)
+	case Blocks:
+		return halfBlocks(scale(img, cols, rows*2))
+	default:
+		return ""
+	}
+}
+
+// fit shrinks the cols by rows box to the image's aspect ratio.
+func fit(bounds image.Rectangle, cols, rows int) (int, int) {
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return 0, 0
+	}
+
+	boxWidth, boxHeight := cols*cellWidth, rows*cellHeight
+	if w*boxHeight > h*boxWidth {
+		// Wider than the box: fill its width.
+		rows = (h*boxWidth/w + cellHeight - 1) / cellHeight
+	} else {
+		cols = (w*boxHeight/h + cellWidth - 1) / cellWidth
+	}
+	return cols, rows
+}
+
+// scale resizes img to width by height pixels, averaging the source pixels
+// that fall into each target pixel.
+func scale(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		if y1 == y0 {
+			y1++
+		}
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			if x1 == x0 {
+				x1++
+			}
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, _ := img.At(sx, sy).RGBA()
+					r, g, b, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), n+1
+				}
+			}
+			out.SetRGBA(x, y, color.RGBA{uint8(r / n >> 8), uint8(g / n >> 8), uint8(b / n >> 8), 0xff})
+		}
+	}
+
+	return out
+}
+
+// halfBlocks draws two pixels per cell with the upper half block: the
+// foreground colours the top pixel and the background the bottom one.
+func halfBlocks(img *image.RGBA) string {
+	bounds := img.Bounds()
+	var b strings.Builder
+
+	for y := 0; y+1 < bounds.Dy(); y += 2 {
+		if y > 0 {
+			b.WriteString("\n")
+		}
+		for x := 0; x < bounds.Dx(); x++ {
+			top, bottom := img.RGBAAt(x, y), img.RGBAAt(x, y+1)
+			fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%dm\x1b[48;2;%d;%d;%dm▀",
+				top.R, top.G, top.B, bottom.R, bottom.G, bottom.B)
+		}
+		b.WriteString("\x1b[0m")
+	}
+
+	return b.String()
+}
+
+// overlay reserves cols by rows blank cells for a graphics escape sequence
+// and emits it from the first cell, restoring the cursor afterwards so the
+// text layout is unaffected by where the terminal leaves it.
+func overlay(sequence string, cols, rows int) string {
+	blank := strings.Repeat(" ", cols)
+	lines := make([]string, rows)
+	for i := range lines {
+		lines[i] = blank
+	}
+	lines[0] = "\x1b7" + sequence + "\x1b8" + blank
+	return strings.Join(lines, "\n")
+}
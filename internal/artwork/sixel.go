@@ -0,0 +1,91 @@
+package artwork
+
+import (
+	"fmt"
+	"image"
+	"strings"
+)
+
+// sixel encodes img as a Sixel image. Colours are quantized to a 6x6x6
+// cube, which every Sixel terminal's palette can hold and which looks fine
+// for posters.
+func sixel(img *image.RGBA) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	indices := make([]int, width*height)
+	var used [216]bool
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := img.RGBAAt(x, y)
+			index := cubeLevel(c.R)*36 + cubeLevel(c.G)*6 + cubeLevel(c.B)
+			indices[y*width+x] = index
+			used[index] = true
+		}
+	}
+
+	var b strings.Builder
+	// P2=1 leaves pixels we don't set transparent.
+	fmt.Fprintf(&b, "\x1bP0;1;0q\"1;1;%d;%d", width, height)
+	for index, ok := range used {
+		if !ok {
+			continue
+		}
+		r, g, bl := index/36, index/6%6, index%6
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", index, r*100/5, g*100/5, bl*100/5)
+	}
+
+	// Sixel rows are bands six pixels high, painted one colour at a time.
+	for top := 0; top < height; top += 6 {
+		var inBand [216]bool
+		for y := top; y < top+6 && y < height; y++ {
+			for x := 0; x < width; x++ {
+				inBand[indices[y*width+x]] = true
+			}
+		}
+
+		for index, ok := range inBand {
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "#%d", index)
+
+			run, previous := 0, byte(0)
+			for x := 0; x < width; x++ {
+				bits := 0
+				for k := 0; k < 6 && top+k < height; k++ {
+					if indices[(top+k)*width+x] == index {
+						bits |= 1 << k
+					}
+				}
+				char := byte(63 + bits)
+				if run > 0 && char != previous {
+					writeRun(&b, previous, run)
+					run = 0
+				}
+				previous = char
+				run++
+			}
+			writeRun(&b, previous, run)
+			b.WriteString("$")
+		}
+		b.WriteString("-")
+	}
+
+	b.WriteString("\x1b\\")
+	return b.String()
+}
+
+func cubeLevel(v uint8) int {
+	return (int(v)*5 + 127) / 255
+}
+
+func writeRun(b *strings.Builder, char byte, run int) {
+	if run > 3 {
+		fmt.Fprintf(b, "!%d%c", run, char)
+		return
+	}
+	for i := 0; i < run; i++ {
+		b.WriteByte(char)
+	}
+}
@@ -1,37 +1,122 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 type Config struct {
 	ServerURL    string `json:"server_url"`
 	DefaultUser  string `json:"default_user"`
 	ItemsPerPage int    `json:"items_per_page"`
+	DeviceID     string `json:"device_id"`
+
+	// APIKey, when set, authenticates every request with a server API key
+	// instead of an interactive login. API keys are not tied to a user, so
+	// UserID selects whose libraries and played state are used.
+	APIKey string `json:"api_key,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+
+	// Player selects the media player: "mpv" (the default), "vlc", or
+	// "command" to run PlayerCommand, a command line that may use the
+	// {url}, {title} and {start} placeholders.
+	Player        string `json:"player,omitempty"`
+	PlayerCommand string `json:"player_command,omitempty"`
+
+	// MaxStreamingBitrate (bits per second) and MaxResolution (video
+	// height) cap what the server streams; anything above them is
+	// transcoded. Zero means no limit.
+	MaxStreamingBitrate int64 `json:"max_streaming_bitrate,omitempty"`
+	MaxResolution       int   `json:"max_resolution,omitempty"`
+
+	// Languages holds each user's preferred audio and subtitle languages,
+	// keyed by user ID.
+	Languages map[string]LanguagePreference `json:"languages,omitempty"`
+
+	// Images selects how artwork is drawn: "kitty", "sixel", "blocks"
+	// (Unicode half blocks), "none", or "auto" (the default) to pick from
+	// the terminal.
+	Images string `json:"images,omitempty"`
+
+	// RequestTimeout is how many seconds a server request may take before
+	// it is abandoned. Zero means the client's default.
+	RequestTimeout int `json:"request_timeout,omitempty"`
 }
 
-func Load() Config {
-	homeDir, err := os.UserHomeDir()
+// Copy returns a copy of c that shares no maps with it, for saving from
+// another goroutine while c may still change.
+func (c Config) Copy() Config {
+	if c.Languages != nil {
+		languages := make(map[string]LanguagePreference, len(c.Languages))
+		for userID, preference := range c.Languages {
+			languages[userID] = preference
+		}
+		c.Languages = languages
+	}
+	return c
+}
+
+// LanguagePreference names languages by the ISO 639-2 codes Jellyfin uses,
+// e.g. "jpn" or "eng". Subtitle may also be "none" to turn subtitles off.
+type LanguagePreference struct {
+	Audio    string `json:"audio,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+// Load reads config.json, or returns the defaults when there is none yet. A
+// file that can't be read or parsed is reported rather than replaced, so a
+// typo doesn't cost the user their settings.
+func Load() (Config, error) {
+	config, err := load()
+	if err != nil {
+		return Config{}, err
+	}
+
+	// The device ID must stay stable across launches so the server keeps
+	// treating us as the same device, so generate it once and persist it.
+	if config.DeviceID == "" {
+		config.DeviceID = newDeviceID()
+		Save(config)
+	}
+
+	return config, nil
+}
+
+func load() (Config, error) {
+	configDir, err := configDir()
 	if err != nil {
-		return defaultConfig()
+		return defaultConfig(), nil
 	}
 
-	configPath := filepath.Join(homeDir, ".config", "jellyfin-tui", "config.json")
+	configPath := filepath.Join(configDir, "config.json")
 	file, err := os.Open(configPath)
+	if os.IsNotExist(err) {
+		return defaultConfig(), nil
+	}
 	if err != nil {
-		return defaultConfig()
+		return Config{}, err
 	}
 	defer file.Close()
 
 	var config Config
-	err = json.NewDecoder(file).Decode(&config)
-	if err != nil {
-		return defaultConfig()
+	if err := json.NewDecoder(file).Decode(&config); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
 	}
 
-	return config
+	return config, nil
+}
+
+func newDeviceID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("jellyfin-tui-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
 }
 
 func defaultConfig() Config {
@@ -42,25 +127,38 @@ func defaultConfig() Config {
 	}
 }
 
-func Save(config Config) error {
+func configDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config", "jellyfin-tui"), nil
+}
+
+func Save(config Config) error {
+	configDir, err := configDir()
 	if err != nil {
 		return err
 	}
 
-	configDir := filepath.Join(homeDir, ".config", "jellyfin-tui")
 	err = os.MkdirAll(configDir, 0755)
 	if err != nil {
 		return err
 	}
 
+	// The file may hold an API key, so like sessions.json it is readable
+	// only by the owner, including when an older version created it.
 	configPath := filepath.Join(configDir, "config.json")
-	file, err := os.Create(configPath)
+	file, err := os.OpenFile(configPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	if err := file.Chmod(0600); err != nil {
+		return err
+	}
+
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(config)
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withHome points the home directory at a fresh temporary directory for the
+// rest of the test and returns the path config.json goes in.
+func withHome(t *testing.T) string {
+	t.Helper()
+	home, err := os.MkdirTemp("", "jellyfin-tui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous, hadHome := os.LookupEnv("HOME")
+	os.Setenv("HOME", home)
+	t.Cleanup(func() {
+		if hadHome {
+			os.Setenv("HOME", previous)
+		} else {
+			os.Unsetenv("HOME")
+		}
+		os.RemoveAll(home)
+	})
+	return filepath.Join(home, ".config", "jellyfin-tui", "config.json")
+}
+
+func writeConfig(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name       string
+		contents   string // empty for no file
+		wantErr    bool
+		wantServer string
+		wantDevice string
+	}{
+		{"missing file", "", false, "http://localhost:8096", ""},
+		{"valid file", `{"server_url": "http://media:8096", "device_id": "device"}`, false, "http://media:8096", "device"},
+		{"malformed file", `{"server_url": "http://media:8096",`, true, "", ""},
+		{"wrong type", `{"items_per_page": "twenty"}`, true, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := withHome(t)
+			if tt.contents != "" {
+				writeConfig(t, path, tt.contents)
+			}
+
+			config, err := Load()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Load succeeded, want an error")
+				}
+				// The file must be left for the user to fix.
+				data, readErr := os.ReadFile(path)
+				if readErr != nil || string(data) != tt.contents {
+					t.Errorf("config.json = %q, %v, want it unchanged", data, readErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			if config.ServerURL != tt.wantServer {
+				t.Errorf("ServerURL = %q, want %q", config.ServerURL, tt.wantServer)
+			}
+			if config.DeviceID == "" || (tt.wantDevice != "" && config.DeviceID != tt.wantDevice) {
+				t.Errorf("DeviceID = %q, want %q or a new one", config.DeviceID, tt.wantDevice)
+			}
+
+			// A new device ID is saved so it stays the same next launch.
+			again, err := Load()
+			if err != nil || again.DeviceID != config.DeviceID {
+				t.Errorf("reloaded DeviceID = %q, %v, want %q", again.DeviceID, err, config.DeviceID)
+			}
+		})
+	}
+}
+
+func TestSaveIsPrivate(t *testing.T) {
+	path := withHome(t)
+	writeConfig(t, path, "{}")
+
+	if err := Save(Config{APIKey: "secret"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("config.json mode = %v, want 0600", mode)
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Session is an authenticated login that can be reused on the next launch.
+// Sessions are stored per server URL in a file readable only by the owner,
+// since the access token grants full access to the user's account.
+type Session struct {
+	ServerURL   string `json:"server_url"`
+	UserID      string `json:"user_id"`
+	UserName    string `json:"user_name"`
+	AccessToken string `json:"access_token"`
+}
+
+func sessionsPath() (string, error) {
+	configDir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "sessions.json"), nil
+}
+
+func loadSessions() map[string]Session {
+	sessions := make(map[string]Session)
+
+	path, err := sessionsPath()
+	if err != nil {
+		return sessions
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return sessions
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(&sessions); err != nil {
+		return make(map[string]Session)
+	}
+
+	return sessions
+}
+
+func saveSessions(sessions map[string]Session) error {
+	path, err := sessionsPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// OpenFile only applies the mode when creating the file, so tighten the
+	// permissions of a file left behind by an older version as well.
+	if err := file.Chmod(0600); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sessions)
+}
+
+func LoadSession(serverURL string) (Session, bool) {
+	session, ok := loadSessions()[serverURL]
+	if !ok || session.AccessToken == "" {
+		return Session{}, false
+	}
+	return session, true
+}
+
+func SaveSession(session Session) error {
+	sessions := loadSessions()
+	sessions[session.ServerURL] = session
+	return saveSessions(sessions)
+}
+
+func DeleteSession(serverURL string) error {
+	sessions := loadSessions()
+	if _, ok := sessions[serverURL]; !ok {
+		return nil
+	}
+	delete(sessions, serverURL)
+	return saveSessions(sessions)
+}
@@ -1,7 +1,10 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
+	"net/http"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -12,46 +15,202 @@ var (
 		Padding(1)
 )
 
+// Kind classifies an AppError. A Kind is itself an error so it can be the
+// target of Is: errors.Is(err, errors.KindNotFound).
+type Kind int
+
+const (
+	// KindAPI is a server response we have no better name for.
+	KindAPI Kind = iota
+	KindNetwork
+	KindTimeout
+	KindAuthentication
+	KindAccountDisabled
+	KindPermission
+	KindNotFound
+	KindInput
+	KindRateLimited
+	KindServer
+	// KindDecode is a response body that isn't what the endpoint returns,
+	// e.g. a proxy's HTML error page.
+	KindDecode
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNetwork:
+		return "Network Error"
+	case KindTimeout:
+		return "Timeout"
+	case KindAuthentication:
+		return "Authentication Error"
+	case KindAccountDisabled:
+		return "Account Disabled"
+	case KindPermission:
+		return "Permission Denied"
+	case KindNotFound:
+		return "Not Found"
+	case KindInput:
+		return "Input Error"
+	case KindRateLimited:
+		return "Rate Limited"
+	case KindServer:
+		return "Server Error"
+	case KindDecode:
+		return "Unexpected Response"
+	default:
+		return "API Error"
+	}
+}
+
+func (k Kind) Error() string {
+	return k.String()
+}
+
+// AppError is an error the UI can explain. StatusCode and Path are set for
+// errors caused by a server response; Cause is the underlying error, if any.
+// Retryable means the same request may succeed if sent again later.
 type AppError struct {
-	Type    string
-	Message string
+	Kind       Kind
+	Message    string
+	StatusCode int
+	Path       string
+	Cause      error
+	Retryable  bool
 }
 
 func (e AppError) Error() string {
-	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Kind, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("%s: %s", e.Kind, e.Message)
 }
 
-func NewNetworkError(message string) AppError {
-	return AppError{
-		Type:    "Network Error",
-		Message: message,
+func (e AppError) Unwrap() error {
+	return e.Cause
+}
+
+// Is matches a Kind, or an AppError of the same kind and, if the target has
+// one, the same status code.
+func (e AppError) Is(target error) bool {
+	switch t := target.(type) {
+	case Kind:
+		return e.Kind == t
+	case AppError:
+		return e.Kind == t.Kind && (t.StatusCode == 0 || e.StatusCode == t.StatusCode)
 	}
+	return false
 }
 
-func NewAuthenticationError(message string) AppError {
-	return AppError{
-		Type:    "Authentication Error",
-		Message: message,
+// Is, As and Unwrap are the standard library's, for code that imports this
+// package in place of it.
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
+
+func Unwrap(err error) error {
+	return stderrors.Unwrap(err)
+}
+
+// KindOf returns the kind of the first AppError in err's chain, or KindAPI
+// when there is none.
+func KindOf(err error) Kind {
+	var appErr AppError
+	if As(err, &appErr) {
+		return appErr.Kind
 	}
+	return KindAPI
 }
 
-func NewInputError(message string) AppError {
+// IsRetryable reports whether err, or an error it wraps, is a retryable
+// AppError.
+func IsRetryable(err error) bool {
+	var appErr AppError
+	return As(err, &appErr) && appErr.Retryable
+}
+
+func New(kind Kind, message string) AppError {
 	return AppError{
-		Type:    "Input Error",
-		Message: message,
+		Kind:      kind,
+		Message:   message,
+		Retryable: kind == KindNetwork || kind == KindTimeout,
 	}
 }
 
-func NewAPIError(message string) AppError {
-	return AppError{
-		Type:    "API Error",
-		Message: message,
+func Wrap(kind Kind, message string, cause error) AppError {
+	err := New(kind, message)
+	err.Cause = cause
+	return err
+}
+
+// FromStatus maps an unsuccessful HTTP status to an AppError for path.
+// Throttling and the gateway errors a restarting server or its proxy return
+// are retryable; other server errors are assumed to recur.
+func FromStatus(status int, path, message string) AppError {
+	err := AppError{
+		Kind:       KindAPI,
+		Message:    message,
+		StatusCode: status,
+		Path:       path,
 	}
+
+	switch {
+	case status == http.StatusUnauthorized:
+		err.Kind = KindAuthentication
+	case status == http.StatusForbidden:
+		err.Kind = KindPermission
+	case status == http.StatusNotFound:
+		err.Kind = KindNotFound
+	case status == http.StatusRequestTimeout:
+		err.Kind = KindTimeout
+		err.Retryable = true
+	case status == http.StatusTooManyRequests:
+		err.Kind = KindRateLimited
+		err.Retryable = true
+	case status >= 500:
+		err.Kind = KindServer
+		err.Retryable = status == http.StatusBadGateway ||
+			status == http.StatusServiceUnavailable ||
+			status == http.StatusGatewayTimeout
+	case status >= 400:
+		err.Kind = KindInput
+	}
+
+	return err
+}
+
+func NewNetworkError(message string) AppError {
+	return New(KindNetwork, message)
+}
+
+func NewAuthenticationError(message string) AppError {
+	return New(KindAuthentication, message)
+}
+
+func NewAccountDisabledError(message string) AppError {
+	return New(KindAccountDisabled, message)
+}
+
+func NewInputError(message string) AppError {
+	return New(KindInput, message)
+}
+
+func NewAPIError(message string) AppError {
+	return New(KindAPI, message)
 }
 
 func FormatError(err error) string {
-	if appErr, ok := err.(AppError); ok {
-		return ErrorStyle.Render(fmt.Sprintf("%s\n%s", appErr.Type, appErr.Message))
+	var appErr AppError
+	if As(err, &appErr) {
+		message := appErr.Message
+		if appErr.Cause != nil {
+			message += ": " + appErr.Cause.Error()
+		}
+		return ErrorStyle.Render(fmt.Sprintf("%s\n%s", appErr.Kind, message))
 	}
 	return ErrorStyle.Render(err.Error())
 }
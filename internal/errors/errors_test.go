@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFromStatus(t *testing.T) {
+	tests := []struct {
+		status        int
+		wantKind      Kind
+		wantRetryable bool
+	}{
+		{400, KindInput, false},
+		{401, KindAuthentication, false},
+		{403, KindPermission, false},
+		{404, KindNotFound, false},
+		{408, KindTimeout, true},
+		{409, KindInput, false},
+		{429, KindRateLimited, true},
+		{500, KindServer, false},
+		{501, KindServer, false},
+		{502, KindServer, true},
+		{503, KindServer, true},
+		{504, KindServer, true},
+		{302, KindAPI, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.status), func(t *testing.T) {
+			err := FromStatus(tt.status, "/Items", "message")
+			if err.Kind != tt.wantKind {
+				t.Errorf("Kind = %v, want %v", err.Kind, tt.wantKind)
+			}
+			if err.Retryable != tt.wantRetryable {
+				t.Errorf("Retryable = %v, want %v", err.Retryable, tt.wantRetryable)
+			}
+			if err.StatusCode != tt.status || err.Path != "/Items" {
+				t.Errorf("StatusCode, Path = %d, %q, want %d, %q", err.StatusCode, err.Path, tt.status, "/Items")
+			}
+		})
+	}
+}
+
+func TestIs(t *testing.T) {
+	wrapped := fmt.Errorf("loading items: %w", FromStatus(404, "/Items", "not found"))
+
+	tests := []struct {
+		name   string
+		target error
+		want   bool
+	}{
+		{"same kind", KindNotFound, true},
+		{"other kind", KindServer, false},
+		{"same kind and status", AppError{Kind: KindNotFound, StatusCode: 404}, true},
+		{"same kind, any status", AppError{Kind: KindNotFound}, true},
+		{"same kind, other status", AppError{Kind: KindNotFound, StatusCode: 410}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Is(wrapped, tt.target); got != tt.want {
+				t.Errorf("Is(err, %v) = %v, want %v", tt.target, got, tt.want)
+			}
+		})
+	}
+
+	if got := KindOf(wrapped); got != KindNotFound {
+		t.Errorf("KindOf = %v, want %v", got, KindNotFound)
+	}
+}
@@ -1,17 +1,47 @@
 package jellyfin
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+)
+
+const (
+	ClientName    = "Jellyfin TUI"
+	ClientVersion = "0.1.0"
 )
 
 type Client struct {
 	BaseURL    string
-	Token      string
+	DeviceName string
+	DeviceID   string
 	HTTPClient *http.Client
+
+	// MaxRetries is how many times a failed GET is sent again; see
+	// roundTrip.
+	MaxRetries int
+
+	// mu guards the credentials, which login and session expiry change from
+	// command goroutines while other requests read them.
+	mu     sync.Mutex
+	token  string
+	userID string
+	// apiKey is set when token holds a server API key rather than a user
+	// access token. API keys are not bound to a user, so the user ID must be
+	// supplied explicitly and there is no session to log out of.
+	apiKey bool
+
+	session *sessionGate
 }
 
 type MediaItem struct {
@@ -21,59 +51,448 @@ type MediaItem struct {
 	Path            string  `json:"Path"`
 	Overview        string  `json:"Overview"`
 	CommunityRating float64 `json:"CommunityRating"`
+	ParentID        string  `json:"ParentId"`
+	IsFolder        bool    `json:"IsFolder"`
+	CollectionType  string  `json:"CollectionType"`
+
+	SeriesID          string       `json:"SeriesId"`
+	SeriesName        string       `json:"SeriesName"`
+	SeasonID          string       `json:"SeasonId"`
+	IndexNumber       int          `json:"IndexNumber"`
+	ParentIndexNumber int          `json:"ParentIndexNumber"`
+	PremiereDate      string       `json:"PremiereDate"`
+	UserData          UserItemData `json:"UserData"`
+	// LocationType is "Virtual" for episodes the server knows of from
+	// metadata but has no file for.
+	LocationType string `json:"LocationType"`
+
+	MediaType    string   `json:"MediaType"`
+	Album        string   `json:"Album"`
+	AlbumID      string   `json:"AlbumId"`
+	AlbumArtist  string   `json:"AlbumArtist"`
+	Artists      []string `json:"Artists"`
+	RunTimeTicks int64    `json:"RunTimeTicks"`
+
+	ProductionYear int          `json:"ProductionYear"`
+	OfficialRating string       `json:"OfficialRating"`
+	CriticRating   float64      `json:"CriticRating"`
+	Genres         []string     `json:"Genres"`
+	Studios        []NameIDPair `json:"Studios"`
+	People         []Person     `json:"People"`
+	Taglines       []string     `json:"Taglines"`
+
+	// ImageTags maps image types such as "Primary" to a tag that changes
+	// with the image.
+	ImageTags map[string]string `json:"ImageTags"`
+
+	// MediaSources is only returned when fetching a single item.
+	MediaSources []MediaSource `json:"MediaSources"`
 }
 
-type Playlist struct {
+// DetailFields are the optional fields requested for the detail view.
+const DetailFields = "Overview,Genres,Studios,People,Taglines,MediaSources,MediaStreams"
+
+type NameIDPair struct {
 	ID   string `json:"Id"`
 	Name string `json:"Name"`
 }
 
-type User struct {
+// Person is a cast or crew member. Type is e.g. "Actor", "Director" or
+// "Writer"; Role is the character played, for actors.
+type Person struct {
+	ID   string `json:"Id"`
+	Name string `json:"Name"`
+	Role string `json:"Role"`
+	Type string `json:"Type"`
+}
+
+// Jellyfin expresses durations and positions in ticks of 100ns.
+const TicksPerSecond = 10000000
+
+func (item MediaItem) Runtime() time.Duration {
+	return time.Duration(item.RunTimeTicks) * 100
+}
+
+// UserItemData is the logged-in user's state for an item. It is only
+// populated by user-scoped requests.
+type UserItemData struct {
+	Played                bool  `json:"Played"`
+	PlayCount             int   `json:"PlayCount"`
+	PlaybackPositionTicks int64 `json:"PlaybackPositionTicks"`
+	UnplayedItemCount     int   `json:"UnplayedItemCount"`
+}
+
+// PlaybackPosition is where the user stopped watching, or zero when there is
+// nothing to resume.
+func (d UserItemData) PlaybackPosition() time.Duration {
+	return time.Duration(d.PlaybackPositionTicks) * 100
+}
+
+type Playlist struct {
 	ID   string `json:"Id"`
 	Name string `json:"Name"`
 }
 
-func NewClient(baseURL string) *Client {
+type User struct {
+	ID          string `json:"Id"`
+	Name        string `json:"Name"`
+	HasPassword bool   `json:"HasPassword"`
+}
+
+type AuthenticationResult struct {
+	User        User   `json:"User"`
+	AccessToken string `json:"AccessToken"`
+	ServerID    string `json:"ServerId"`
+}
+
+func NewClient(baseURL, deviceID string) *Client {
+	deviceName, err := os.Hostname()
+	if err != nil || deviceName == "" {
+		deviceName = "terminal"
+	}
+
 	return &Client{
 		BaseURL:    baseURL,
-		HTTPClient: &http.Client{},
+		DeviceName: deviceName,
+		DeviceID:   deviceID,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		MaxRetries: DefaultMaxRetries,
+		session:    newSessionGate(),
 	}
 }
 
-func (c *Client) Login(username, password string) error {
-	data := url.Values{}
-	data.Set("Username", username)
-	data.Set("Pw", password)
+func (c *Client) UseAPIKey(key, userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = key
+	c.userID = userID
+	c.apiKey = true
+}
+
+func (c *Client) UsingAPIKey() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.apiKey
+}
+
+// Token is the access token or API key requests are made with.
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// UserID is the user requests are made for.
+func (c *Client) UserID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.userID
+}
 
-	resp, err := c.HTTPClient.PostForm(c.BaseURL+"/Users/AuthenticateByName", data)
+// SetSession switches to a user access token, e.g. one saved from an
+// earlier login.
+func (c *Client) SetSession(token, userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+	c.userID = userID
+}
+
+func (c *Client) setUserID(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.userID = userID
+}
+
+// expireToken forgets token, unless it has already been replaced by a new
+// login, which it reports.
+func (c *Client) expireToken(token string) (replaced bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && c.token != token {
+		return true
+	}
+	c.token = ""
+	return false
+}
+
+func (c *Client) ClearCredentials() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+	c.userID = ""
+	c.apiKey = false
+}
+
+// authorizationHeader identifies this client to the server. Jellyfin uses the
+// Client/Device/DeviceId/Version fields to populate its Devices and Sessions
+// dashboards, and some server versions refuse requests that omit them.
+func (c *Client) authorizationHeader(token string) string {
+	fields := []string{
+		fmt.Sprintf("Client=%q", ClientName),
+		fmt.Sprintf("Device=%q", c.DeviceName),
+		fmt.Sprintf("DeviceId=%q", c.DeviceID),
+		fmt.Sprintf("Version=%q", ClientVersion),
+	}
+	if token != "" {
+		fields = append(fields, fmt.Sprintf("Token=%q", token))
+	}
+	return "MediaBrowser " + strings.Join(fields, ", ")
+}
+
+func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.BaseURL+path, body)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	req.Header.Set("X-Emby-Authorization", c.authorizationHeader(c.Token()))
+	return req, nil
+}
+
+// userPath scopes path to the logged-in user. The user-scoped endpoints
+// apply parental controls, library access and per-user played state, all of
+// which the global /Items endpoints ignore.
+func (c *Client) userPath(path string) (string, error) {
+	if err := c.requireUser(); err != nil {
+		return "", err
+	}
+	return "/Users/" + c.UserID() + path, nil
+}
+
+func (c *Client) requireUser() error {
+	if c.UserID() == "" {
+		return errors.NewAuthenticationError("no user is logged in")
+	}
+	return nil
+}
+
+// getItems fetches an item query result from path. Most list endpoints share
+// the {Items, TotalRecordCount} response shape.
+func (c *Client) getItems(path string, query url.Values) ([]MediaItem, int, error) {
+	req, err := c.newRequest("GET", path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("authentication failed: %s", resp.Status)
+	if err := checkStatus(resp, "failed to fetch "+path); err != nil {
+		return nil, 0, err
 	}
 
 	var result struct {
-		AccessToken string `json:"AccessToken"`
+		Items            []MediaItem `json:"Items"`
+		TotalRecordCount int         `json:"TotalRecordCount"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return err
+	if err := decodeJSON(resp, &result); err != nil {
+		return nil, 0, err
+	}
+
+	return result.Items, result.TotalRecordCount, nil
+}
+
+// send sends req, retrying it as roundTrip allows, and maps transport
+// failures and rejected API keys to AppErrors.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		kind := errors.KindNetwork
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			kind = errors.KindTimeout
+		}
+		appErr := errors.Wrap(kind, "could not reach "+c.BaseURL, err)
+		appErr.Path = req.URL.Path
+		return nil, appErr
+	}
+
+	if c.UsingAPIKey() && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		resp.Body.Close()
+		appErr := errors.FromStatus(resp.StatusCode, req.URL.Path, fmt.Sprintf(
+			"API key is not authorized for %s as user %s: %s", req.URL.Path, c.UserID(), resp.Status))
+		appErr.Kind = errors.KindAuthentication
+		return nil, appErr
+	}
+
+	return resp, nil
+}
+
+// checkStatus returns nil for a successful response and otherwise an
+// AppError describing it, with the server's explanation if it gave one.
+func checkStatus(resp *http.Response, action string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	message := fmt.Sprintf("%s: %s", action, resp.Status)
+	if detail := serverMessage(resp); detail != "" {
+		message += " (" + detail + ")"
+	}
+	return errors.FromStatus(resp.StatusCode, resp.Request.URL.Path, message)
+}
+
+// serverMessage extracts a short explanation from an error response body.
+// Jellyfin answers with plain text or a JSON problem document; HTML pages,
+// usually from a reverse proxy, are not worth showing.
+func serverMessage(resp *http.Response) string {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return ""
 	}
 
-	c.Token = result.AccessToken
+	contentType := resp.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "json"):
+		var problem struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		}
+		if json.Unmarshal(body, &problem) != nil {
+			return ""
+		}
+		if problem.Detail != "" {
+			return problem.Detail
+		}
+		return problem.Title
+	case strings.HasPrefix(contentType, "text/plain"):
+		text := strings.Join(strings.Fields(string(body)), " ")
+		if len(text) > 200 {
+			text = text[:200] + "..."
+		}
+		return text
+	default:
+		return ""
+	}
+}
+
+// decodeJSON decodes a successful response into v.
+func decodeJSON(resp *http.Response, v interface{}) error {
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		path := resp.Request.URL.Path
+		appErr := errors.Wrap(errors.KindDecode, "unexpected response from "+path, err)
+		appErr.StatusCode = resp.StatusCode
+		appErr.Path = path
+		return appErr
+	}
 	return nil
 }
 
-func (c *Client) GetMediaItems(page, itemsPerPage int, filter string) ([]MediaItem, int, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/Items", nil)
+func (c *Client) Login(username, password string) (*AuthenticationResult, error) {
+	result, err := c.authenticate(username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetSession(result.AccessToken, result.User.ID)
+	return result, nil
+}
+
+// authenticate exchanges credentials for an access token without touching
+// the client's current session, so a failed attempt leaves it usable.
+func (c *Client) authenticate(username, password string) (*AuthenticationResult, error) {
+	body, err := json.Marshal(map[string]string{
+		"Username": username,
+		"Pw":       password,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest("POST", "/Users/AuthenticateByName", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return nil, errors.FromStatus(resp.StatusCode, req.URL.Path, "invalid username or password")
+	case http.StatusForbidden:
+		err := errors.FromStatus(resp.StatusCode, req.URL.Path, fmt.Sprintf("user %q is not allowed to sign in", username))
+		err.Kind = errors.KindAccountDisabled
+		return nil, err
+	}
+	if err := checkStatus(resp, "authentication failed"); err != nil {
+		return nil, err
+	}
+
+	var result AuthenticationResult
+	if err := decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetViews returns the user's library views (Movies, Shows, Music, ...), the
+// top level of the browse hierarchy.
+func (c *Client) GetViews() ([]MediaItem, error) {
+	path, err := c.userPath("/Views")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "failed to fetch library views"); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items []MediaItem `json:"Items"`
+	}
+
+	if err := decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
+// GetMediaItems lists the children of parentID, or every item in the user's
+// libraries when parentID is empty. A filter searches the whole subtree for
+// items of that type instead of listing direct children.
+func (c *Client) GetMediaItems(parentID string, page, itemsPerPage int, filter string) ([]MediaItem, int, error) {
+	path, err := c.userPath("/Items")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := c.newRequest("GET", path, nil)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	q := req.URL.Query()
+	if parentID != "" {
+		q.Add("ParentId", parentID)
+	}
+	if parentID == "" || filter != "" {
+		q.Add("Recursive", "true")
+	}
+	q.Add("SortBy", "IsFolder,SortName")
+	q.Add("SortOrder", "Descending,Ascending")
 	q.Add("StartIndex", fmt.Sprintf("%d", (page-1)*itemsPerPage))
 	q.Add("Limit", fmt.Sprintf("%d", itemsPerPage))
 	if filter != "" {
@@ -81,20 +500,22 @@ func (c *Client) GetMediaItems(page, itemsPerPage int, filter string) ([]MediaIt
 	}
 	req.URL.RawQuery = q.Encode()
 
-	req.Header.Set("X-Emby-Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", c.Token))
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp, "failed to fetch items"); err != nil {
+		return nil, 0, err
+	}
+
 	var result struct {
 		Items            []MediaItem `json:"Items"`
 		TotalRecordCount int         `json:"TotalRecordCount"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := decodeJSON(resp, &result); err != nil {
 		return nil, 0, err
 	}
 
@@ -102,21 +523,31 @@ func (c *Client) GetMediaItems(page, itemsPerPage int, filter string) ([]MediaIt
 }
 
 func (c *Client) GetItemDetails(itemID string) (*MediaItem, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/Items/"+itemID, nil)
+	path, err := c.userPath("/Items/" + itemID)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("X-Emby-Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", c.Token))
+	q := url.Values{}
+	q.Set("Fields", DetailFields)
 
-	resp, err := c.HTTPClient.Do(req)
+	req, err := c.newRequest("GET", path+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp, "failed to fetch item details"); err != nil {
+		return nil, err
+	}
+
 	var item MediaItem
-	if err := json.NewDecoder(resp.Body).Decode(&item); err != nil {
+	if err := decodeJSON(resp, &item); err != nil {
 		return nil, err
 	}
 
@@ -124,28 +555,36 @@ func (c *Client) GetItemDetails(itemID string) (*MediaItem, error) {
 }
 
 func (c *Client) Search(query string) ([]MediaItem, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/Items", nil)
+	path, err := c.userPath("/Items")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest("GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	q := req.URL.Query()
 	q.Add("SearchTerm", query)
+	q.Add("Recursive", "true")
 	req.URL.RawQuery = q.Encode()
 
-	req.Header.Set("X-Emby-Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", c.Token))
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp, "search failed"); err != nil {
+		return nil, err
+	}
+
 	var result struct {
 		Items []MediaItem `json:"Items"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := decodeJSON(resp, &result); err != nil {
 		return nil, err
 	}
 
@@ -153,24 +592,26 @@ func (c *Client) Search(query string) ([]MediaItem, error) {
 }
 
 func (c *Client) GetPlaylists() ([]Playlist, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/Playlists", nil)
+	req, err := c.newRequest("GET", "/Playlists", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("X-Emby-Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", c.Token))
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp, "failed to fetch playlists"); err != nil {
+		return nil, err
+	}
+
 	var result struct {
 		Items []Playlist `json:"Items"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := decodeJSON(resp, &result); err != nil {
 		return nil, err
 	}
 
@@ -178,29 +619,35 @@ func (c *Client) GetPlaylists() ([]Playlist, error) {
 }
 
 func (c *Client) AddToPlaylist(playlistID, itemID string) error {
-	url := fmt.Sprintf("%s/Playlists/%s/Items?Ids=%s", c.BaseURL, playlistID, itemID)
-	req, err := http.NewRequest("POST", url, nil)
+	req, err := c.newRequest("POST", fmt.Sprintf("/Playlists/%s/Items?Ids=%s", playlistID, itemID), nil)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("X-Emby-Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", c.Token))
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to add item to playlist: %s", resp.Status)
+	if err := checkStatus(resp, "failed to add item to playlist"); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-func (c *Client) GetStreamURL(itemID string) string {
-	return fmt.Sprintf("%s/Videos/%s/stream?static=true&api_key=%s", c.BaseURL, itemID, c.Token)
+// GetPlaylistItems lists a playlist's entries in playlist order.
+func (c *Client) GetPlaylistItems(playlistID string) ([]MediaItem, error) {
+	if err := c.requireUser(); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("UserId", c.UserID())
+
+	items, _, err := c.getItems(fmt.Sprintf("/Playlists/%s/Items", playlistID), q)
+	return items, err
 }
 
 func (c *Client) CreatePlaylist(name string) error {
@@ -208,52 +655,193 @@ func (c *Client) CreatePlaylist(name string) error {
 	data.Set("Name", name)
 	data.Set("MediaType", "Video")
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/Playlists", strings.NewReader(data.Encode()))
+	req, err := c.newRequest("POST", "/Playlists", strings.NewReader(data.Encode()))
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("X-Emby-Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", c.Token))
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to create playlist: %s", resp.Status)
+	if err := checkStatus(resp, "failed to create playlist"); err != nil {
+		return err
 	}
 
 	return nil
 }
 
 func (c *Client) GetUsers() ([]User, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/Users", nil)
+	req, err := c.newRequest("GET", "/Users", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("X-Emby-Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", c.Token))
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "failed to list users"); err != nil {
+		return nil, err
+	}
+
+	var users []User
+	if err := decodeJSON(resp, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetPublicUsers lists the users shown on the server's login screen. It does
+// not require authentication.
+func (c *Client) GetPublicUsers() ([]User, error) {
+	req, err := c.newRequest("GET", "/Users/Public", nil)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp, "failed to list users"); err != nil {
+		return nil, err
+	}
+
 	var users []User
-	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
+	if err := decodeJSON(resp, &users); err != nil {
 		return nil, err
 	}
 
 	return users, nil
 }
 
-func (c *Client) SwitchUser(userID string) error {
-	// This is a placeholder. The actual implementation would depend on how
-	// Jellyfin handles user switching. You might need to re-authenticate
-	// as the new user, or there might be a specific API endpoint for switching users.
-	return fmt.Errorf("SwitchUser not implemented")
+func (c *Client) GetCurrentUser() (*User, error) {
+	req, err := c.newRequest("GET", "/Users/Me", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, errors.FromStatus(resp.StatusCode, req.URL.Path, "stored session is no longer valid")
+	}
+	if err := checkStatus(resp, "failed to validate session"); err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := decodeJSON(resp, &user); err != nil {
+		return nil, err
+	}
+
+	c.setUserID(user.ID)
+	return &user, nil
+}
+
+func (c *Client) GetUser(userID string) (*User, error) {
+	req, err := c.newRequest("GET", "/Users/"+userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.FromStatus(resp.StatusCode, req.URL.Path, fmt.Sprintf("user %s does not exist", userID))
+	}
+	if err := checkStatus(resp, "failed to get user"); err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := decodeJSON(resp, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// Logout revokes the current access token on the server. The local token is
+// cleared even if the server cannot be reached. API keys are never revoked,
+// since they are managed from the server dashboard.
+func (c *Client) Logout() error {
+	defer c.ClearCredentials()
+	return c.revokeToken(c.Token())
+}
+
+func (c *Client) revokeToken(token string) error {
+	if token == "" || c.UsingAPIKey() {
+		return nil
+	}
+
+	req, err := c.newRequest("POST", "/Sessions/Logout", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Emby-Authorization", c.authorizationHeader(token))
+
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "failed to log out"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SwitchUser re-authenticates as another user and swaps the client over to
+// their session, revoking the previous token. On failure the current session
+// is left untouched. With an API key no password is needed; only the user
+// context changes. beforeSwitch, if not nil, runs once the switch can go
+// ahead, while requests are still made as the previous user.
+func (c *Client) SwitchUser(user User, password string, beforeSwitch func()) (*AuthenticationResult, error) {
+	if c.UsingAPIKey() {
+		if beforeSwitch != nil {
+			beforeSwitch()
+		}
+		c.setUserID(user.ID)
+		return &AuthenticationResult{User: user, AccessToken: c.Token()}, nil
+	}
+
+	result, err := c.authenticate(user.Name, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if beforeSwitch != nil {
+		beforeSwitch()
+	}
+
+	// The new session is in place before the old token is revoked, so
+	// requests still running with it fail rather than look like an expired
+	// session. Losing track of the old token is harmless; it can still be
+	// revoked from the server dashboard.
+	previous := c.Token()
+	c.SetSession(result.AccessToken, result.User.ID)
+	c.session.abandon(previous)
+	c.revokeToken(previous)
+	return result, nil
 }
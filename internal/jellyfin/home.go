@@ -0,0 +1,73 @@
+package jellyfin
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// GetResumeItems lists videos the user has started but not finished, most
+// recently watched first.
+func (c *Client) GetResumeItems(limit int) ([]MediaItem, error) {
+	path, err := c.userPath("/Items/Resume")
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("Limit", strconv.Itoa(limit))
+	q.Set("MediaTypes", "Video")
+
+	items, _, err := c.getItems(path, q)
+	return items, err
+}
+
+// GetNextUp lists the next episode to watch in each series the user is
+// following.
+func (c *Client) GetNextUp(limit int) ([]MediaItem, error) {
+	if err := c.requireUser(); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("UserId", c.UserID())
+	q.Set("Limit", strconv.Itoa(limit))
+
+	items, _, err := c.getItems("/Shows/NextUp", q)
+	return items, err
+}
+
+// GetLatest lists the items most recently added to a library. New episodes
+// of the same series are grouped into the series.
+func (c *Client) GetLatest(libraryID string, limit int) ([]MediaItem, error) {
+	path, err := c.userPath("/Items/Latest")
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("ParentId", libraryID)
+	q.Set("Limit", strconv.Itoa(limit))
+
+	req, err := c.newRequest("GET", path+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "failed to fetch latest items"); err != nil {
+		return nil, err
+	}
+
+	// Unlike other item queries, Latest returns a bare array.
+	var items []MediaItem
+	if err := decodeJSON(resp, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
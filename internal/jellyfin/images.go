@@ -0,0 +1,49 @@
+package jellyfin
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// Image types, as used in MediaItem.ImageTags.
+const (
+	ImagePrimary  = "Primary"
+	ImageBackdrop = "Backdrop"
+	ImageThumb    = "Thumb"
+)
+
+// ImageTag returns the tag of the item's image of the given type, which
+// changes whenever the image does. Items without such an image return "".
+func (item MediaItem) ImageTag(imageType string) string {
+	return item.ImageTags[imageType]
+}
+
+// GetImage downloads one of the item's images as JPEG, scaled by the server
+// to fit within maxWidth by maxHeight pixels.
+func (c *Client) GetImage(itemID, imageType string, maxWidth, maxHeight int) ([]byte, error) {
+	q := url.Values{}
+	q.Set("maxWidth", strconv.Itoa(maxWidth))
+	q.Set("maxHeight", strconv.Itoa(maxHeight))
+	q.Set("format", "Jpg")
+	q.Set("quality", "90")
+
+	path := fmt.Sprintf("/Items/%s/Images/%s", itemID, imageType)
+	req, err := c.newRequest("GET", path+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "failed to fetch "+imageType+" image"); err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,74 @@
+package jellyfin
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// GetAlbumArtists lists the album artists in a music library.
+func (c *Client) GetAlbumArtists(libraryID string) ([]MediaItem, error) {
+	if err := c.requireUser(); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("userId", c.UserID())
+	q.Set("ParentId", libraryID)
+	q.Set("SortBy", "SortName")
+
+	artists, _, err := c.getItems("/Artists/AlbumArtists", q)
+	return artists, err
+}
+
+func (c *Client) GetAlbums(artistID string) ([]MediaItem, error) {
+	path, err := c.userPath("/Items")
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("AlbumArtistIds", artistID)
+	q.Set("IncludeItemTypes", "MusicAlbum")
+	q.Set("Recursive", "true")
+	q.Set("SortBy", "ProductionYear,SortName")
+
+	albums, _, err := c.getItems(path, q)
+	return albums, err
+}
+
+// GetTracks lists an album's tracks ordered by disc and track number.
+func (c *Client) GetTracks(albumID string) ([]MediaItem, error) {
+	path, err := c.userPath("/Items")
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("ParentId", albumID)
+	q.Set("IncludeItemTypes", "Audio")
+	q.Set("Recursive", "true")
+	q.Set("SortBy", "ParentIndexNumber,IndexNumber,SortName")
+
+	tracks, _, err := c.getItems(path, q)
+	return tracks, err
+}
+
+// GetAudioStreamURL returns a universal audio stream URL. The server direct
+// streams any of the listed containers and transcodes everything else, or
+// anything above maxBitrate when it is not zero.
+func (c *Client) GetAudioStreamURL(itemID string, maxBitrate int64) string {
+	q := url.Values{}
+	q.Set("UserId", c.UserID())
+	q.Set("DeviceId", c.DeviceID)
+	q.Set("api_key", c.Token())
+	q.Set("Container", "opus,webm|opus,mp3,aac,m4a|aac,m4b|aac,flac,webma,webm|webma,wav,ogg")
+	q.Set("TranscodingContainer", "mp3")
+	q.Set("TranscodingProtocol", "http")
+	q.Set("AudioCodec", "mp3")
+	if maxBitrate > 0 {
+		q.Set("MaxStreamingBitrate", strconv.FormatInt(maxBitrate, 10))
+	}
+
+	return fmt.Sprintf("%s/Audio/%s/universal?%s", c.BaseURL, itemID, q.Encode())
+}
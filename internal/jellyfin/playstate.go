@@ -0,0 +1,65 @@
+package jellyfin
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// PlaybackProgressInfo is the body of the /Sessions/Playing reports that keep
+// the server's played state, Continue Watching and active sessions current.
+// PositionTicks is nil when the position is unknown, which leaves the
+// server's resume point alone.
+type PlaybackProgressInfo struct {
+	ItemID        string `json:"ItemId"`
+	MediaSourceID string `json:"MediaSourceId,omitempty"`
+	PlaySessionID string `json:"PlaySessionId,omitempty"`
+	PlayMethod    string `json:"PlayMethod,omitempty"`
+	PositionTicks *int64 `json:"PositionTicks,omitempty"`
+	IsPaused      bool   `json:"IsPaused"`
+	CanSeek       bool   `json:"CanSeek"`
+}
+
+func (c *Client) ReportPlaybackStart(info PlaybackProgressInfo) error {
+	return c.postJSON("/Sessions/Playing", info)
+}
+
+func (c *Client) ReportPlaybackProgress(info PlaybackProgressInfo) error {
+	return c.postJSON("/Sessions/Playing/Progress", info)
+}
+
+func (c *Client) ReportPlaybackStopped(info PlaybackProgressInfo) error {
+	return c.postJSON("/Sessions/Playing/Stopped", info)
+}
+
+func (c *Client) MarkPlayed(itemID string) error {
+	path, err := c.userPath("/PlayedItems/" + itemID)
+	if err != nil {
+		return err
+	}
+	return c.postJSON(path, nil)
+}
+
+func (c *Client) postJSON(path string, v interface{}) error {
+	var body bytes.Buffer
+	if v != nil {
+		if err := json.NewEncoder(&body).Encode(v); err != nil {
+			return err
+		}
+	}
+
+	req, err := c.newRequest("POST", path, &body)
+	if err != nil {
+		return err
+	}
+	if v != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkStatus(resp, "request to "+path+" failed")
+}
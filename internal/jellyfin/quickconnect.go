@@ -0,0 +1,160 @@
+package jellyfin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+)
+
+// QuickConnectState describes a pending Quick Connect request. The Code is
+// shown to the user, who approves it from an already signed-in client; the
+// Secret is what this client uses to poll for and redeem the approval.
+type QuickConnectState struct {
+	Authenticated bool   `json:"Authenticated"`
+	Secret        string `json:"Secret"`
+	Code          string `json:"Code"`
+}
+
+func (c *Client) QuickConnectEnabled() (bool, error) {
+	req, err := c.newRequest("GET", "/QuickConnect/Enabled", nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "failed to query Quick Connect"); err != nil {
+		return false, err
+	}
+
+	var enabled bool
+	if err := decodeJSON(resp, &enabled); err != nil {
+		return false, err
+	}
+
+	return enabled, nil
+}
+
+func (c *Client) InitiateQuickConnect() (*QuickConnectState, error) {
+	// Jellyfin 10.9 changed Initiate from GET to POST; fall back to GET so
+	// older servers keep working.
+	state, status, err := c.initiateQuickConnect("POST")
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotFound) {
+		state, status, err = c.initiateQuickConnect("GET")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case http.StatusOK:
+		return state, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, errors.NewAuthenticationError("Quick Connect is disabled on this server")
+	default:
+		return nil, errors.FromStatus(status, "/QuickConnect/Initiate", fmt.Sprintf(
+			"failed to start Quick Connect: %d %s", status, http.StatusText(status)))
+	}
+}
+
+func (c *Client) initiateQuickConnect(method string) (*QuickConnectState, int, error) {
+	req, err := c.newRequest(method, "/QuickConnect/Initiate", nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode, nil
+	}
+
+	var state QuickConnectState
+	if err := decodeJSON(resp, &state); err != nil {
+		return nil, 0, err
+	}
+
+	return &state, resp.StatusCode, nil
+}
+
+func (c *Client) GetQuickConnectState(secret string) (*QuickConnectState, error) {
+	req, err := c.newRequest("GET", "/QuickConnect/Connect?secret="+url.QueryEscape(secret), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		err := errors.FromStatus(resp.StatusCode, req.URL.Path, "Quick Connect code expired")
+		err.Kind = errors.KindAuthentication
+		return nil, err
+	}
+	if err := checkStatus(resp, "failed to check Quick Connect state"); err != nil {
+		return nil, err
+	}
+
+	var state QuickConnectState
+	if err := decodeJSON(resp, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func (c *Client) AuthenticateWithQuickConnect(secret string) (*AuthenticationResult, error) {
+	body, err := json.Marshal(map[string]string{"Secret": secret})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest("POST", "/Users/AuthenticateWithQuickConnect", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusNotFound:
+		err := errors.FromStatus(resp.StatusCode, req.URL.Path, "Quick Connect request was not authorized")
+		err.Kind = errors.KindAuthentication
+		return nil, err
+	case http.StatusForbidden:
+		err := errors.FromStatus(resp.StatusCode, req.URL.Path, "the approving user is not allowed to sign in")
+		err.Kind = errors.KindAccountDisabled
+		return nil, err
+	}
+	if err := checkStatus(resp, "Quick Connect authentication failed"); err != nil {
+		return nil, err
+	}
+
+	var result AuthenticationResult
+	if err := decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	c.SetSession(result.AccessToken, result.User.ID)
+	return &result, nil
+}
@@ -0,0 +1,129 @@
+package jellyfin
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+)
+
+const (
+	// DefaultTimeout bounds a whole request, including reading the
+	// response, so a hung server cannot stall a command forever.
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxRetries is how many times a failed GET is sent again.
+	DefaultMaxRetries = 3
+
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 8 * time.Second
+	// maxRetryAfter is the longest Retry-After we are willing to wait out;
+	// a server asking for more gets its error reported instead.
+	maxRetryAfter = 30 * time.Second
+)
+
+// roundTrip sends req, retrying GETs after network errors and retryable
+// statuses (throttling and server errors) with jittered exponential backoff,
+// or after the delay the server's Retry-After asks for. Other methods are
+// sent once, since they may not be safe to repeat. Timeouts are not retried:
+// the user has already waited the full timeout once.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.HTTPClient.Do(req)
+		if req.Method != http.MethodGet || attempt >= c.MaxRetries || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				if after > maxRetryAfter {
+					return resp, nil
+				}
+				delay = after
+			}
+			// Draining lets the connection be reused.
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// shouldRetry reports whether a request that ended in resp or err may
+// succeed if sent again.
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return transientError(err)
+	}
+	if resp.StatusCode < 400 {
+		return false
+	}
+	return errors.FromStatus(resp.StatusCode, "", "").Retryable
+}
+
+// transientError reports whether a transport error is the kind a dropped or
+// refused connection causes. Timeouts, cancellation and errors about the
+// request itself, like an unsupported URL scheme, are not.
+func transientError(err error) bool {
+	// Client.Do wraps everything in a *url.Error, which is itself a
+	// net.Error, so look at what it wraps.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && !netErr.Timeout()
+}
+
+// backoff returns the delay before retry attempt+1: exponential, capped,
+// with the upper half randomised so concurrent requests don't retry in
+// lockstep.
+func backoff(attempt int) time.Duration {
+	delay := retryBaseDelay << uint(attempt)
+	if delay > retryMaxDelay || delay <= 0 {
+		delay = retryMaxDelay
+	}
+	half := int64(delay / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// retryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
@@ -0,0 +1,172 @@
+package jellyfin
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func transportError(err error) error {
+	return &url.Error{Op: "Get", URL: "http://jellyfin.local/Items", Err: err}
+}
+
+func TestShouldRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   bool
+	}{
+		{"ok", http.StatusOK, nil, false},
+		{"not found", http.StatusNotFound, nil, false},
+		{"unauthorized", http.StatusUnauthorized, nil, false},
+		{"throttled", http.StatusTooManyRequests, nil, true},
+		{"internal error", http.StatusInternalServerError, nil, false},
+		{"bad gateway", http.StatusBadGateway, nil, true},
+		{"unavailable", http.StatusServiceUnavailable, nil, true},
+		{"gateway timeout", http.StatusGatewayTimeout, nil, true},
+		{"connection refused", 0, transportError(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), true},
+		{"connection reset", 0, transportError(&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		{"connection closed", 0, transportError(io.EOF), true},
+		{"unknown host", 0, transportError(&net.DNSError{Err: "no such host", Name: "jellyfin.local"}), true},
+		{"timeout", 0, transportError(timeoutError{}), false},
+		{"cancelled", 0, transportError(context.Canceled), false},
+		{"deadline", 0, transportError(context.DeadlineExceeded), false},
+		{"bad request", 0, transportError(stderrors.New(`unsupported protocol scheme "ftp"`)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status}
+			}
+			if got := shouldRetry(resp, tt.err); got != tt.want {
+				t.Errorf("shouldRetry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"missing", "", 0, false},
+		{"seconds", "5", 5 * time.Second, true},
+		{"zero", "0", 0, true},
+		{"negative", "-1", 0, false},
+		{"past date", "Mon, 02 Jan 2006 15:04:05 GMT", 0, true},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			got, ok := retryAfter(resp)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryAfter = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	t.Run("future date", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+		got, ok := retryAfter(resp)
+		if !ok || got <= 50*time.Second || got > time.Minute {
+			t.Errorf("retryAfter = %v, %v, want about a minute", got, ok)
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{0, retryBaseDelay},
+		{1, 2 * retryBaseDelay},
+		{2, 4 * retryBaseDelay},
+		{4, retryMaxDelay},
+		{10, retryMaxDelay},
+		{100, retryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempt), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if got := backoff(tt.attempt); got < tt.max/2 || got > tt.max {
+					t.Fatalf("backoff(%d) = %v, want between %v and %v", tt.attempt, got, tt.max/2, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{"get recovers", http.MethodGet, []int{503, 502, 200}, 200, 3},
+		{"get gives up", http.MethodGet, []int{503, 503, 503, 503, 503}, 503, DefaultMaxRetries + 1},
+		{"get not retried", http.MethodGet, []int{500, 200}, 500, 1},
+		{"post not retried", http.MethodPost, []int{503, 200}, 503, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			c := NewClient(server.URL, "test-device")
+			req, err := http.NewRequest(tt.method, server.URL+"/Items", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.roundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("sent %d times, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package jellyfin
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+)
+
+// ErrSessionEnded is the cause of the error returned by requests that were
+// waiting on a re-login that was abandoned, or that logged in as someone
+// else.
+var ErrSessionEnded = fmt.Errorf("the session ended before the request could be replayed")
+
+// sessionGate holds requests back while the user logs in again after the
+// server rejected the access token, e.g. because it was revoked from the
+// dashboard. The first request to see the 401 reports it on expired; every
+// request waits for ResumeSession and is then replayed with the new token.
+type sessionGate struct {
+	mu      sync.Mutex
+	relogin *relogin
+	expired chan struct{}
+	// abandoned is the token of the last session that was not resumed.
+	// Requests sent with it that are only now rejected must not be replayed
+	// as whoever logged in instead.
+	abandoned string
+}
+
+type relogin struct {
+	token string
+	done  chan struct{}
+	ok    bool
+}
+
+func newSessionGate() *sessionGate {
+	return &sessionGate{expired: make(chan struct{}, 1)}
+}
+
+// wait blocks while a re-login is pending. It reports whether it waited and,
+// if so, whether the session was resumed.
+func (g *sessionGate) wait() (waited, ok bool) {
+	g.mu.Lock()
+	pending := g.relogin
+	g.mu.Unlock()
+
+	if pending == nil {
+		return false, true
+	}
+	<-pending.done
+	return true, pending.ok
+}
+
+// expire handles a 401 for a request sent with token and blocks until the
+// session is resumed or abandoned, reporting which.
+func (g *sessionGate) expire(c *Client, token string) bool {
+	g.mu.Lock()
+	if token == g.abandoned {
+		g.mu.Unlock()
+		return false
+	}
+	if g.relogin == nil {
+		// The login requests must not carry the dead token, so it is
+		// cleared, unless someone already logged in again since the request
+		// was sent.
+		if c.expireToken(token) {
+			g.mu.Unlock()
+			return true
+		}
+		g.relogin = &relogin{token: token, done: make(chan struct{})}
+		select {
+		case g.expired <- struct{}{}:
+		default:
+		}
+	}
+	pending := g.relogin
+	g.mu.Unlock()
+
+	<-pending.done
+	return pending.ok
+}
+
+func (g *sessionGate) resume(ok bool) {
+	g.mu.Lock()
+	pending := g.relogin
+	g.relogin = nil
+	if pending != nil && !ok {
+		g.abandoned = pending.token
+	}
+	g.mu.Unlock()
+
+	if pending != nil {
+		pending.ok = ok
+		close(pending.done)
+	}
+}
+
+// abandon makes requests sent with token that are rejected from now on fail
+// instead of being replayed, because someone else has logged in.
+func (g *sessionGate) abandon(token string) {
+	g.mu.Lock()
+	g.abandoned = token
+	g.mu.Unlock()
+}
+
+// SessionExpired receives when the server stops accepting the access token.
+// Requests made with it wait until ResumeSession is called.
+func (c *Client) SessionExpired() <-chan struct{} {
+	return c.session.expired
+}
+
+// ResumeSession releases the requests waiting since the session expired.
+// With replay they are sent again with the new token, otherwise they fail
+// with ErrSessionEnded; pass false when a different user logged in.
+func (c *Client) ResumeSession(replay bool) {
+	c.session.resume(replay)
+}
+
+// do sends req, waiting out and recovering from an expired session. API
+// keys don't expire this way, and the login requests use send directly,
+// since for them a 401 means the credentials were wrong.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.UsingAPIKey() {
+		return c.send(req)
+	}
+
+	if waited, ok := c.session.wait(); waited && !ok {
+		return nil, sessionEnded(req)
+	}
+
+	// The token may have changed since the request was built.
+	token := c.Token()
+	req.Header.Set("X-Emby-Authorization", c.authorizationHeader(token))
+	resp, err := c.send(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || token == "" {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	if !c.session.expire(c, token) {
+		return nil, sessionEnded(req)
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	retry.Header.Set("X-Emby-Authorization", c.authorizationHeader(c.Token()))
+	return c.send(retry)
+}
+
+func sessionEnded(req *http.Request) error {
+	err := errors.FromStatus(http.StatusUnauthorized, req.URL.Path, "session expired")
+	err.Cause = ErrSessionEnded
+	return err
+}
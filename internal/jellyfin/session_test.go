@@ -0,0 +1,207 @@
+package jellyfin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+)
+
+// sessionServer accepts only the "fresh" token, reporting every request it
+// rejects on rejected, and answers with the body it was sent so a test can
+// tell a replayed POST kept it.
+func sessionServer(rejected chan<- struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("X-Emby-Authorization"), `Token="fresh"`) {
+			w.WriteHeader(http.StatusUnauthorized)
+			rejected <- struct{}{}
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+}
+
+type result struct {
+	body string
+	err  error
+}
+
+func sendAsync(c *Client, method, body string) <-chan result {
+	done := make(chan result, 1)
+	go func() {
+		req, err := c.newRequest(method, "/Items", strings.NewReader(body))
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		resp, err := c.do(req)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		done <- result{body: string(data)}
+	}()
+	return done
+}
+
+func receive(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatal(what)
+	}
+}
+
+func TestSessionReplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests int
+		method   string
+		replay   bool
+	}{
+		{"get replayed", 1, http.MethodGet, true},
+		{"post replayed with its body", 1, http.MethodPost, true},
+		{"concurrent requests replayed", 3, http.MethodGet, true},
+		{"abandoned", 1, http.MethodGet, false},
+		{"concurrent requests abandoned", 3, http.MethodPost, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejected := make(chan struct{}, tt.requests)
+			server := sessionServer(rejected)
+			defer server.Close()
+
+			c := NewClient(server.URL, "test-device")
+			c.SetSession("stale", "user")
+
+			var results []<-chan result
+			for i := 0; i < tt.requests; i++ {
+				results = append(results, sendAsync(c, tt.method, "payload"))
+			}
+
+			receive(t, c.SessionExpired(), "the session never expired")
+			for i := 0; i < tt.requests; i++ {
+				receive(t, rejected, "a request never reached the server")
+			}
+			if c.Token() != "" {
+				t.Errorf("token = %q after expiring, want it cleared", c.Token())
+			}
+			if tt.replay {
+				c.SetSession("fresh", "user")
+			}
+			c.ResumeSession(tt.replay)
+
+			for _, done := range results {
+				var r result
+				select {
+				case r = <-done:
+				case <-time.After(5 * time.Second):
+					t.Fatal("request still waiting after the session resumed")
+				}
+
+				if !tt.replay {
+					if !errors.Is(r.err, ErrSessionEnded) {
+						t.Errorf("err = %v, want ErrSessionEnded", r.err)
+					}
+					continue
+				}
+				if r.err != nil {
+					t.Errorf("replay failed: %v", r.err)
+				} else if tt.method == http.MethodPost && r.body != "payload" {
+					t.Errorf("replayed body = %q, want %q", r.body, "payload")
+				}
+			}
+
+			// Concurrent 401s must ask for a single login.
+			select {
+			case <-c.SessionExpired():
+				t.Error("the session expired twice")
+			default:
+			}
+		})
+	}
+}
+
+func TestSessionExpiryAfterRelogin(t *testing.T) {
+	// A 401 for a token that has since been replaced is replayed at once
+	// instead of asking for another login.
+	c := NewClient("http://jellyfin.local", "test-device")
+	c.SetSession("fresh", "user")
+
+	if !c.session.expire(c, "stale") {
+		t.Error("expire = false, want the request replayed")
+	}
+
+	select {
+	case <-c.SessionExpired():
+		t.Error("a replaced token expired the session")
+	default:
+	}
+	if c.Token() != "fresh" {
+		t.Errorf("token = %q, want %q", c.Token(), "fresh")
+	}
+}
+
+func TestSessionExpiryAfterOtherUserLogin(t *testing.T) {
+	// A 401 that arrives after the user gave up on the session and logged in
+	// as someone else must not be replayed as them.
+	c := NewClient("http://jellyfin.local", "test-device")
+	c.SetSession("stale", "user")
+
+	expired := make(chan bool, 1)
+	go func() { expired <- c.session.expire(c, "stale") }()
+	receive(t, c.SessionExpired(), "the session never expired")
+	c.SetSession("other", "other-user")
+	c.ResumeSession(false)
+	if <-expired {
+		t.Error("expire = true for the abandoned session, want false")
+	}
+
+	if c.session.expire(c, "stale") {
+		t.Error("a late 401 for the abandoned session was replayed")
+	}
+}
+
+func TestSwitchUserRevokesOldTokenLast(t *testing.T) {
+	var c *Client
+	var revoked, tokenWhenRevoked string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Users/AuthenticateByName":
+			w.Write([]byte(`{"User": {"Id": "other", "Name": "Other"}, "AccessToken": "fresh"}`))
+		case "/Sessions/Logout":
+			revoked = r.Header.Get("X-Emby-Authorization")
+			tokenWhenRevoked = c.Token()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	c = NewClient(server.URL, "test-device")
+	c.SetSession("stale", "user")
+
+	if _, err := c.SwitchUser(User{ID: "other", Name: "Other"}, "password", nil); err != nil {
+		t.Fatalf("SwitchUser: %v", err)
+	}
+	if !strings.Contains(revoked, `Token="stale"`) {
+		t.Errorf("revoked with %q, want the old token", revoked)
+	}
+	if tokenWhenRevoked != "fresh" {
+		t.Errorf("token while revoking = %q, want the new one", tokenWhenRevoked)
+	}
+	if c.session.expire(c, "stale") {
+		t.Error("a 401 for the previous user's token was replayed as the new user")
+	}
+	if c.Token() != "fresh" || c.UserID() != "other" {
+		t.Errorf("session = %q, %q, want %q, %q", c.Token(), c.UserID(), "fresh", "other")
+	}
+}
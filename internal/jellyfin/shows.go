@@ -0,0 +1,57 @@
+package jellyfin
+
+import (
+	"fmt"
+	"net/url"
+)
+
+func (c *Client) GetSeasons(seriesID string) ([]MediaItem, error) {
+	if err := c.requireUser(); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("userId", c.UserID())
+
+	seasons, _, err := c.getItems(fmt.Sprintf("/Shows/%s/Seasons", seriesID), q)
+	return seasons, err
+}
+
+// GetEpisodes lists a series' episodes in airing order, limited to one season
+// when seasonID is non-empty.
+func (c *Client) GetEpisodes(seriesID, seasonID string) ([]MediaItem, error) {
+	if err := c.requireUser(); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("userId", c.UserID())
+	q.Set("Fields", "Overview")
+	if seasonID != "" {
+		q.Set("seasonId", seasonID)
+	}
+
+	episodes, _, err := c.getItems(fmt.Sprintf("/Shows/%s/Episodes", seriesID), q)
+	return episodes, err
+}
+
+// GetNextEpisode returns the episode to watch next in a series, the one after
+// the last one played, or nil when the server has none to suggest. Like the
+// Next Up row it skips specials.
+func (c *Client) GetNextEpisode(seriesID string) (*MediaItem, error) {
+	if err := c.requireUser(); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("UserId", c.UserID())
+	q.Set("SeriesId", seriesID)
+	q.Set("Fields", "Overview")
+	q.Set("Limit", "1")
+
+	items, _, err := c.getItems("/Shows/NextUp", q)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
@@ -0,0 +1,320 @@
+package jellyfin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+)
+
+// Play methods, as reported back to the server in playback reports.
+const (
+	PlayMethodDirectPlay   = "DirectPlay"
+	PlayMethodDirectStream = "DirectStream"
+	PlayMethodTranscode    = "Transcode"
+)
+
+// StreamOptions limits what the server may send and picks the tracks to
+// play. Zero limits mean no limit.
+type StreamOptions struct {
+	// MaxBitrate is in bits per second.
+	MaxBitrate int64
+	// MaxHeight caps the video resolution, e.g. 720 or 1080.
+	MaxHeight int
+
+	Tracks TrackSelection
+	// AudioLanguage and SubtitleLanguage fill in whatever Tracks leaves
+	// open; see MediaSource.PreferredTracks.
+	AudioLanguage    string
+	SubtitleLanguage string
+}
+
+// Stream is a negotiated stream for one item.
+type Stream struct {
+	URL           string
+	PlayMethod    string
+	MediaSourceID string
+	PlaySessionID string
+
+	// AudioTrack and SubtitleTrack number the chosen tracks the way players
+	// do, from 1; zero leaves the choice to the player and a SubtitleTrack
+	// of -1 turns subtitles off. SubtitleURL is an external subtitle file
+	// to load alongside the stream.
+	AudioTrack    int
+	SubtitleTrack int
+	SubtitleURL   string
+}
+
+// MediaSource is one version of an item as described by PlaybackInfo, with
+// the server's verdict on how it can be delivered under our device profile.
+type MediaSource struct {
+	ID                     string `json:"Id"`
+	Container              string `json:"Container"`
+	Bitrate                int64  `json:"Bitrate"`
+	SupportsDirectPlay     bool   `json:"SupportsDirectPlay"`
+	SupportsDirectStream   bool   `json:"SupportsDirectStream"`
+	SupportsTranscoding    bool   `json:"SupportsTranscoding"`
+	TranscodingURL         string `json:"TranscodingUrl"`
+	TranscodingSubProtocol string `json:"TranscodingSubProtocol"`
+
+	MediaStreams               []MediaStream `json:"MediaStreams"`
+	DefaultAudioStreamIndex    *int          `json:"DefaultAudioStreamIndex"`
+	DefaultSubtitleStreamIndex *int          `json:"DefaultSubtitleStreamIndex"`
+}
+
+type PlaybackInfo struct {
+	MediaSources  []MediaSource `json:"MediaSources"`
+	PlaySessionID string        `json:"PlaySessionId"`
+	ErrorCode     string        `json:"ErrorCode"`
+}
+
+// deviceProfile describes what mpv and VLC can play. Both decode nearly
+// anything, so direct play is limited to widespread codecs that play
+// smoothly on modest laptops; everything else is transcoded to H.264/AAC
+// over HLS.
+func deviceProfile(opts StreamOptions) map[string]interface{} {
+	profile := map[string]interface{}{
+		"Name": ClientName,
+		"DirectPlayProfiles": []map[string]string{
+			{
+				"Type":       "Video",
+				"Container":  "mkv,mp4,m4v,mov,webm,ts,avi",
+				"VideoCodec": "h264,hevc,vp8,vp9,av1,mpeg4",
+				"AudioCodec": "aac,mp3,opus,vorbis,flac,ac3,eac3,dts,truehd,alac,pcm_s16le,pcm_s24le",
+			},
+			{"Type": "Audio"},
+		},
+		"TranscodingProfiles": []map[string]string{
+			{
+				"Type":       "Video",
+				"Container":  "ts",
+				"Protocol":   "hls",
+				"Context":    "Streaming",
+				"VideoCodec": "h264",
+				"AudioCodec": "aac",
+			},
+			{
+				"Type":       "Audio",
+				"Container":  "mp3",
+				"Protocol":   "http",
+				"Context":    "Streaming",
+				"AudioCodec": "mp3",
+			},
+		},
+		"SubtitleProfiles": []map[string]string{
+			{"Format": "srt", "Method": "External"},
+			{"Format": "ass", "Method": "External"},
+			{"Format": "ssa", "Method": "External"},
+			{"Format": "vtt", "Method": "External"},
+			{"Format": "pgssub", "Method": "Embed"},
+			{"Format": "dvdsub", "Method": "Embed"},
+		},
+	}
+
+	if opts.MaxBitrate > 0 {
+		profile["MaxStreamingBitrate"] = opts.MaxBitrate
+	}
+	if opts.MaxHeight > 0 {
+		profile["CodecProfiles"] = []map[string]interface{}{
+			{
+				"Type": "Video",
+				"Conditions": []map[string]interface{}{
+					{
+						"Condition":  "LessThanEqual",
+						"Property":   "Height",
+						"Value":      strconv.Itoa(opts.MaxHeight),
+						"IsRequired": false,
+					},
+				},
+			},
+		}
+	}
+
+	return profile
+}
+
+// GetPlaybackInfo asks the server how it can deliver itemID to a device
+// with our profile and limits.
+func (c *Client) GetPlaybackInfo(itemID string, opts StreamOptions) (*PlaybackInfo, error) {
+	if err := c.requireUser(); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("UserId", c.UserID())
+	q.Set("AutoOpenLiveStream", "true")
+	if opts.MaxBitrate > 0 {
+		q.Set("MaxStreamingBitrate", strconv.FormatInt(opts.MaxBitrate, 10))
+	}
+	if opts.Tracks.MediaSourceID != "" {
+		q.Set("MediaSourceId", opts.Tracks.MediaSourceID)
+	}
+	if opts.Tracks.AudioStreamIndex != nil {
+		q.Set("AudioStreamIndex", strconv.Itoa(*opts.Tracks.AudioStreamIndex))
+	}
+	if opts.Tracks.SubtitleStreamIndex != nil {
+		q.Set("SubtitleStreamIndex", strconv.Itoa(*opts.Tracks.SubtitleStreamIndex))
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"DeviceProfile": deviceProfile(opts),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/Items/%s/PlaybackInfo", itemID)
+	req, err := c.newRequest("POST", path+"?"+q.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "failed to get playback info"); err != nil {
+		return nil, err
+	}
+
+	var info PlaybackInfo
+	if err := decodeJSON(resp, &info); err != nil {
+		return nil, err
+	}
+	if info.ErrorCode != "" {
+		return nil, errors.NewAPIError(fmt.Sprintf("server cannot play this item: %s", info.ErrorCode))
+	}
+
+	return &info, nil
+}
+
+// ResolveStream negotiates the best stream for item: direct play when the
+// file fits our profile and limits, direct stream when only the container
+// needs changing, and an HLS transcode otherwise.
+func (c *Client) ResolveStream(item MediaItem, opts StreamOptions) (*Stream, error) {
+	if item.MediaType == "Audio" {
+		// The universal endpoint negotiates audio by itself.
+		return &Stream{
+			URL:           c.GetAudioStreamURL(item.ID, opts.MaxBitrate),
+			PlayMethod:    PlayMethodDirectStream,
+			MediaSourceID: item.ID,
+		}, nil
+	}
+
+	info, source, err := c.negotiate(item, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only now are the item's tracks known, so language preferences can be
+	// applied. A transcode is built around the tracks asked for, so ask
+	// again with them.
+	tracks := source.PreferredTracks(opts.Tracks, opts.AudioLanguage, opts.SubtitleLanguage)
+	if tracks != opts.Tracks && !source.SupportsDirectPlay {
+		opts.Tracks = tracks
+		if info, source, err = c.negotiate(item, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	stream := &Stream{
+		MediaSourceID: source.ID,
+		PlaySessionID: info.PlaySessionID,
+	}
+
+	switch {
+	case source.SupportsDirectPlay:
+		stream.PlayMethod = PlayMethodDirectPlay
+		stream.URL = c.staticStreamURL(item.ID, source, info.PlaySessionID, "")
+	case source.SupportsDirectStream:
+		stream.PlayMethod = PlayMethodDirectStream
+		stream.URL = c.staticStreamURL(item.ID, source, info.PlaySessionID, "."+source.Container)
+	case source.SupportsTranscoding && source.TranscodingURL != "":
+		stream.PlayMethod = PlayMethodTranscode
+		stream.URL = c.transcodingURL(source.TranscodingURL, opts)
+	default:
+		return nil, errors.NewAPIError(fmt.Sprintf("the server offers no way to stream %s", item.Name))
+	}
+
+	stream.AudioTrack, stream.SubtitleTrack, stream.SubtitleURL = c.playerTracks(item.ID, source, tracks, stream.PlayMethod)
+
+	return stream, nil
+}
+
+// negotiate fetches PlaybackInfo for item and picks the media source asked
+// for, or the server's first choice.
+func (c *Client) negotiate(item MediaItem, opts StreamOptions) (*PlaybackInfo, MediaSource, error) {
+	info, err := c.GetPlaybackInfo(item.ID, opts)
+	if err != nil {
+		return nil, MediaSource{}, err
+	}
+	if len(info.MediaSources) == 0 {
+		return nil, MediaSource{}, errors.NewAPIError(fmt.Sprintf("%s has no playable media sources", item.Name))
+	}
+
+	for _, source := range info.MediaSources {
+		if source.ID == opts.Tracks.MediaSourceID {
+			return info, source, nil
+		}
+	}
+	return info, info.MediaSources[0], nil
+}
+
+func (c *Client) staticStreamURL(itemID string, source MediaSource, playSessionID, extension string) string {
+	q := url.Values{}
+	q.Set("Static", "true")
+	q.Set("MediaSourceId", source.ID)
+	q.Set("PlaySessionId", playSessionID)
+	q.Set("DeviceId", c.DeviceID)
+	q.Set("api_key", c.Token())
+
+	return fmt.Sprintf("%s/Videos/%s/stream%s?%s", c.BaseURL, itemID, extension, q.Encode())
+}
+
+// transcodingURL completes the relative URL the server built for the
+// transcode, adding the resolution cap and our credentials.
+func (c *Client) transcodingURL(path string, opts StreamOptions) string {
+	u := c.BaseURL + path
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	q := url.Values{}
+	if opts.MaxHeight > 0 && !hasQueryParam(path, "MaxHeight") {
+		q.Set("MaxHeight", strconv.Itoa(opts.MaxHeight))
+	}
+	// Current servers put the token in the URL as ApiKey, older ones as
+	// api_key.
+	if !hasQueryParam(path, "api_key") && !hasQueryParam(path, "ApiKey") {
+		q.Set("api_key", c.Token())
+	}
+	if len(q) == 0 {
+		return u
+	}
+	return u + separator + q.Encode()
+}
+
+// hasQueryParam reports whether the query of path sets name, which, like
+// the server, it matches regardless of case.
+func hasQueryParam(path, name string) bool {
+	i := strings.Index(path, "?")
+	if i < 0 {
+		return false
+	}
+	// ParseQuery returns what it could parse along with any error.
+	query, _ := url.ParseQuery(path[i+1:])
+	for key := range query {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package jellyfin
+
+import "testing"
+
+func TestTranscodingURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		maxHeight int
+		want      string
+	}{
+		{
+			"adds the token",
+			"/videos/item/master.m3u8?MediaSourceId=source",
+			0,
+			"http://jellyfin.local/videos/item/master.m3u8?MediaSourceId=source&api_key=token",
+		},
+		{
+			"keeps an ApiKey",
+			"/videos/item/master.m3u8?MediaSourceId=source&ApiKey=server",
+			0,
+			"http://jellyfin.local/videos/item/master.m3u8?MediaSourceId=source&ApiKey=server",
+		},
+		{
+			"keeps an api_key",
+			"/videos/item/master.m3u8?api_key=server",
+			0,
+			"http://jellyfin.local/videos/item/master.m3u8?api_key=server",
+		},
+		{
+			"adds the resolution cap",
+			"/videos/item/master.m3u8?ApiKey=server",
+			720,
+			"http://jellyfin.local/videos/item/master.m3u8?ApiKey=server&MaxHeight=720",
+		},
+		{
+			"keeps the server's resolution cap",
+			"/videos/item/master.m3u8?maxHeight=480&ApiKey=server",
+			720,
+			"http://jellyfin.local/videos/item/master.m3u8?maxHeight=480&ApiKey=server",
+		},
+		{
+			"no query",
+			"/videos/item/stream.mkv",
+			0,
+			"http://jellyfin.local/videos/item/stream.mkv?api_key=token",
+		},
+	}
+
+	c := NewClient("http://jellyfin.local", "test-device")
+	c.SetSession("token", "user")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.transcodingURL(tt.path, StreamOptions{MaxHeight: tt.maxHeight})
+			if got != tt.want {
+				t.Errorf("transcodingURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package jellyfin
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// MediaStream is one video, audio or subtitle stream of a media source.
+// Index numbers streams across the whole source, including subtitle files
+// stored next to the media.
+type MediaStream struct {
+	Index          int    `json:"Index"`
+	Type           string `json:"Type"`
+	Codec          string `json:"Codec"`
+	Language       string `json:"Language"`
+	Title          string `json:"Title"`
+	DisplayTitle   string `json:"DisplayTitle"`
+	IsDefault      bool   `json:"IsDefault"`
+	IsForced       bool   `json:"IsForced"`
+	IsExternal     bool   `json:"IsExternal"`
+	DeliveryMethod string `json:"DeliveryMethod"`
+	DeliveryURL    string `json:"DeliveryUrl"`
+
+	Width         int    `json:"Width"`
+	Height        int    `json:"Height"`
+	Channels      int    `json:"Channels"`
+	ChannelLayout string `json:"ChannelLayout"`
+	BitRate       int64  `json:"BitRate"`
+	VideoRange    string `json:"VideoRange"`
+}
+
+// Label describes the stream for a track list, e.g. "Japanese - AAC - 5.1".
+func (s MediaStream) Label() string {
+	if s.DisplayTitle != "" {
+		return s.DisplayTitle
+	}
+	parts := []string{}
+	for _, part := range []string{s.Title, s.Language, strings.ToUpper(s.Codec)} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("Track %d", s.Index)
+	}
+	return strings.Join(parts, " - ")
+}
+
+// NoSubtitles is the subtitle stream index that turns subtitles off.
+const NoSubtitles = -1
+
+// TrackSelection picks the media source and the audio and subtitle streams
+// to play, by MediaStream.Index. Nil indices leave the choice open.
+type TrackSelection struct {
+	MediaSourceID       string
+	AudioStreamIndex    *int
+	SubtitleStreamIndex *int
+}
+
+// DefaultSource returns the item's first media source; items with several
+// versions list the preferred one first.
+func (item MediaItem) DefaultSource() (MediaSource, bool) {
+	if len(item.MediaSources) == 0 {
+		return MediaSource{}, false
+	}
+	return item.MediaSources[0], true
+}
+
+// Streams returns the source's streams of the given type ("Audio",
+// "Subtitle" or "Video") in index order.
+func (s MediaSource) Streams(kind string) []MediaStream {
+	var streams []MediaStream
+	for _, stream := range s.MediaStreams {
+		if stream.Type == kind {
+			streams = append(streams, stream)
+		}
+	}
+	return streams
+}
+
+func (s MediaSource) stream(index int) (MediaStream, bool) {
+	for _, stream := range s.MediaStreams {
+		if stream.Index == index {
+			return stream, true
+		}
+	}
+	return MediaStream{}, false
+}
+
+// PreferredTracks fills the open choices in tracks from language
+// preferences, given as the ISO 639-2 codes Jellyfin uses ("jpn", "eng").
+// A subtitle language of "none" turns subtitles off.
+func (s MediaSource) PreferredTracks(tracks TrackSelection, audioLanguage, subtitleLanguage string) TrackSelection {
+	if tracks.AudioStreamIndex == nil && audioLanguage != "" {
+		for _, stream := range s.Streams("Audio") {
+			if strings.EqualFold(stream.Language, audioLanguage) {
+				index := stream.Index
+				tracks.AudioStreamIndex = &index
+				break
+			}
+		}
+	}
+
+	if tracks.SubtitleStreamIndex == nil && subtitleLanguage != "" {
+		if subtitleLanguage == "none" {
+			index := NoSubtitles
+			tracks.SubtitleStreamIndex = &index
+		} else if stream, ok := s.subtitleInLanguage(subtitleLanguage); ok {
+			index := stream.Index
+			tracks.SubtitleStreamIndex = &index
+		}
+	}
+
+	return tracks
+}
+
+// subtitleInLanguage prefers full subtitles over forced ones, which only
+// cover foreign-language dialogue.
+func (s MediaSource) subtitleInLanguage(language string) (MediaStream, bool) {
+	var forced *MediaStream
+	for _, stream := range s.Streams("Subtitle") {
+		if !strings.EqualFold(stream.Language, language) {
+			continue
+		}
+		if !stream.IsForced {
+			return stream, true
+		}
+		if forced == nil {
+			stream := stream
+			forced = &stream
+		}
+	}
+	if forced != nil {
+		return *forced, true
+	}
+	return MediaStream{}, false
+}
+
+// playerTracks translates tracks into the numbering players use: from 1
+// among the tracks of each kind actually in the stream, with an external
+// subtitle file loaded after the embedded ones. A transcode carries only the
+// audio track the server picked, and burns in subtitles it cannot deliver
+// as a file.
+func (c *Client) playerTracks(itemID string, source MediaSource, tracks TrackSelection, playMethod string) (audio, subtitle int, subtitleURL string) {
+	transcoding := playMethod == PlayMethodTranscode
+
+	if tracks.AudioStreamIndex != nil && !transcoding {
+		for i, stream := range source.embedded("Audio") {
+			if stream.Index == *tracks.AudioStreamIndex {
+				audio = i + 1
+			}
+		}
+	}
+
+	if tracks.SubtitleStreamIndex == nil {
+		return audio, 0, ""
+	}
+	if *tracks.SubtitleStreamIndex == NoSubtitles {
+		return audio, -1, ""
+	}
+
+	chosen, ok := source.stream(*tracks.SubtitleStreamIndex)
+	if !ok {
+		return audio, 0, ""
+	}
+
+	embedded := source.embedded("Subtitle")
+	if !chosen.IsExternal && !transcoding {
+		for i, stream := range embedded {
+			if stream.Index == chosen.Index {
+				subtitle = i + 1
+			}
+		}
+		return audio, subtitle, ""
+	}
+
+	if chosen.IsExternal || chosen.DeliveryMethod == "External" {
+		if transcoding {
+			embedded = nil
+		}
+		return audio, len(embedded) + 1, c.subtitleURL(itemID, source, chosen)
+	}
+
+	return audio, 0, ""
+}
+
+func (s MediaSource) embedded(kind string) []MediaStream {
+	var streams []MediaStream
+	for _, stream := range s.Streams(kind) {
+		if !stream.IsExternal {
+			streams = append(streams, stream)
+		}
+	}
+	return streams
+}
+
+// subtitleURL returns the server's subtitle endpoint for stream, which
+// converts it to a format players can load.
+func (c *Client) subtitleURL(itemID string, source MediaSource, stream MediaStream) string {
+	path := stream.DeliveryURL
+	if path == "" {
+		path = fmt.Sprintf("/Videos/%s/%s/Subtitles/%d/0/Stream.%s", itemID, source.ID, stream.Index, subtitleFormat(stream.Codec))
+	}
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return c.BaseURL + path + separator + url.Values{"api_key": {c.Token()}}.Encode()
+}
+
+func subtitleFormat(codec string) string {
+	switch strings.ToLower(codec) {
+	case "ass", "ssa":
+		return strings.ToLower(codec)
+	case "webvtt", "vtt":
+		return "vtt"
+	default:
+		return "srt"
+	}
+}
@@ -0,0 +1,123 @@
+package jellyfin
+
+import "testing"
+
+var testSource = MediaSource{
+	ID: "source",
+	MediaStreams: []MediaStream{
+		{Index: 0, Type: "Video", Codec: "h264"},
+		{Index: 1, Type: "Audio", Language: "eng"},
+		{Index: 2, Type: "Audio", Language: "jpn"},
+		{Index: 3, Type: "Subtitle", Language: "eng", IsForced: true},
+		{Index: 4, Type: "Subtitle", Language: "eng"},
+		{Index: 5, Type: "Subtitle", Language: "jpn", Codec: "subrip", DeliveryMethod: "External", DeliveryURL: "/Videos/item/source/Subtitles/5/0/Stream.srt?CopyTimestamps=true"},
+		{Index: 6, Type: "Subtitle", Language: "spa", IsForced: true},
+		{Index: 7, Type: "Subtitle", Language: "fre", Codec: "ass", IsExternal: true},
+	},
+}
+
+func index(i int) *int {
+	return &i
+}
+
+func TestPreferredTracks(t *testing.T) {
+	tests := []struct {
+		name             string
+		tracks           TrackSelection
+		audioLanguage    string
+		subtitleLanguage string
+		wantAudio        *int
+		wantSubtitle     *int
+	}{
+		{"no preferences", TrackSelection{}, "", "", nil, nil},
+		{"audio language", TrackSelection{}, "jpn", "", index(2), nil},
+		{"audio language in other case", TrackSelection{}, "JPN", "", index(2), nil},
+		{"missing audio language", TrackSelection{}, "kor", "", nil, nil},
+		{"full subtitles over forced", TrackSelection{}, "", "eng", nil, index(4)},
+		{"only forced subtitles", TrackSelection{}, "", "spa", nil, index(6)},
+		{"subtitles off", TrackSelection{}, "", "none", nil, index(NoSubtitles)},
+		{"missing subtitle language", TrackSelection{}, "", "kor", nil, nil},
+		{"both", TrackSelection{}, "jpn", "eng", index(2), index(4)},
+		{
+			"keeps the user's choice",
+			TrackSelection{AudioStreamIndex: index(1), SubtitleStreamIndex: index(NoSubtitles)},
+			"jpn", "eng",
+			index(1), index(NoSubtitles),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testSource.PreferredTracks(tt.tracks, tt.audioLanguage, tt.subtitleLanguage)
+			if !sameIndex(got.AudioStreamIndex, tt.wantAudio) {
+				t.Errorf("audio = %v, want %v", show(got.AudioStreamIndex), show(tt.wantAudio))
+			}
+			if !sameIndex(got.SubtitleStreamIndex, tt.wantSubtitle) {
+				t.Errorf("subtitle = %v, want %v", show(got.SubtitleStreamIndex), show(tt.wantSubtitle))
+			}
+		})
+	}
+}
+
+func sameIndex(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func show(i *int) interface{} {
+	if i == nil {
+		return "nil"
+	}
+	return *i
+}
+
+func TestPlayerTracks(t *testing.T) {
+	tests := []struct {
+		name            string
+		tracks          TrackSelection
+		playMethod      string
+		wantAudio       int
+		wantSubtitle    int
+		wantSubtitleURL string
+	}{
+		{"nothing chosen", TrackSelection{}, PlayMethodDirectPlay, 0, 0, ""},
+		{"audio", TrackSelection{AudioStreamIndex: index(2)}, PlayMethodDirectPlay, 2, 0, ""},
+		{"audio when transcoding", TrackSelection{AudioStreamIndex: index(2)}, PlayMethodTranscode, 0, 0, ""},
+		{"subtitles off", TrackSelection{SubtitleStreamIndex: index(NoSubtitles)}, PlayMethodDirectPlay, 0, -1, ""},
+		{"embedded subtitle", TrackSelection{SubtitleStreamIndex: index(4)}, PlayMethodDirectStream, 0, 2, ""},
+		{"unknown subtitle", TrackSelection{SubtitleStreamIndex: index(42)}, PlayMethodDirectPlay, 0, 0, ""},
+		{
+			"external subtitle",
+			TrackSelection{SubtitleStreamIndex: index(7)}, PlayMethodDirectPlay,
+			0, 5, "http://jellyfin.local/Videos/item/source/Subtitles/7/0/Stream.ass?api_key=token",
+		},
+		{
+			"external subtitle when transcoding",
+			TrackSelection{SubtitleStreamIndex: index(7)}, PlayMethodTranscode,
+			0, 1, "http://jellyfin.local/Videos/item/source/Subtitles/7/0/Stream.ass?api_key=token",
+		},
+		{
+			"subtitle delivered as a file when transcoding",
+			TrackSelection{SubtitleStreamIndex: index(5)}, PlayMethodTranscode,
+			0, 1, "http://jellyfin.local/Videos/item/source/Subtitles/5/0/Stream.srt?CopyTimestamps=true&api_key=token",
+		},
+		{"subtitle burned in when transcoding", TrackSelection{SubtitleStreamIndex: index(4)}, PlayMethodTranscode, 0, 0, ""},
+	}
+
+	c := NewClient("http://jellyfin.local", "test-device")
+	c.SetSession("token", "user")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audio, subtitle, subtitleURL := c.playerTracks("item", testSource, tt.tracks, tt.playMethod)
+			if audio != tt.wantAudio || subtitle != tt.wantSubtitle {
+				t.Errorf("tracks = %d, %d, want %d, %d", audio, subtitle, tt.wantAudio, tt.wantSubtitle)
+			}
+			if subtitleURL != tt.wantSubtitleURL {
+				t.Errorf("subtitle URL = %q, want %q", subtitleURL, tt.wantSubtitleURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package player
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Command plays media by running a user-supplied command line. The template
+// is split on whitespace and each field may contain the placeholders {url},
+// {title} and {start}, the start position in whole seconds. Such players can
+// only be started and stopped, and report nothing but their exit.
+type Command struct {
+	fields []string
+
+	mu      sync.Mutex
+	current *commandProcess
+}
+
+func NewCommand(template string) (*Command, error) {
+	fields := strings.Fields(template)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("player is \"command\" but player_command is empty")
+	}
+	return &Command{fields: fields}, nil
+}
+
+func (c *Command) program() string {
+	return c.fields[0]
+}
+
+// Play runs the command for url, killing any earlier instance.
+func (c *Command) Play(url string, opts Options) (<-chan interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current != nil {
+		c.current.stop()
+	}
+
+	replacer := strings.NewReplacer(
+		"{url}", url,
+		"{title}", opts.Title,
+		"{start}", strconv.Itoa(int(opts.Start/time.Second)),
+	)
+	args := make([]string, len(c.fields))
+	for i, field := range c.fields {
+		args[i] = replacer.Replace(field)
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	if err := cmd.Start(); err != nil {
+		c.current = nil
+		return nil, err
+	}
+
+	p := &commandProcess{
+		cmd:    cmd,
+		events: make(chan interface{}, 1),
+	}
+	go func() {
+		err := cmd.Wait()
+
+		p.mu.Lock()
+		finished := !p.stopped && err == nil
+		p.mu.Unlock()
+
+		c.mu.Lock()
+		if c.current == p {
+			c.current = nil
+		}
+		c.mu.Unlock()
+
+		p.events <- EndEvent{Finished: finished, Err: err}
+		close(p.events)
+	}()
+
+	c.current = p
+	return p.events, nil
+}
+
+func (c *Command) Stop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current == nil {
+		return ErrNotPlaying
+	}
+	return c.current.stop()
+}
+
+type commandProcess struct {
+	cmd    *exec.Cmd
+	events chan interface{}
+
+	mu      sync.Mutex
+	stopped bool
+}
+
+func (p *commandProcess) stop() error {
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+
+	// The player may have exited before Wait got to clear current.
+	err := p.cmd.Process.Kill()
+	if errors.Is(err, os.ErrProcessDone) {
+		return ErrNotPlaying
+	}
+	return err
+}
@@ -0,0 +1,304 @@
+package player
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Property observer IDs, echoed back by mpv in property-change events.
+const (
+	observeTimePos = iota + 1
+	observeDuration
+	observePause
+	observeVolume
+	observeAudioTrack
+	observeSubtitleTrack
+)
+
+// MPV plays media in mpv, controlling it over its JSON IPC protocol through
+// the Unix socket given to --input-ipc-server.
+type MPV struct {
+	mu      sync.Mutex
+	current *mpvProcess
+}
+
+func NewMPV() *MPV {
+	return &MPV{}
+}
+
+// Play launches mpv for url, quitting any earlier instance. It returns once
+// mpv is accepting commands; playback continues in the background.
+func (m *MPV) Play(url string, opts Options) (<-chan interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current != nil {
+		m.current.command("quit")
+	}
+
+	process, err := startMPV(url, opts)
+	if err != nil {
+		m.current = nil
+		return nil, err
+	}
+	m.current = process
+	return process.events, nil
+}
+
+func (m *MPV) Stop() error {
+	return m.command("quit")
+}
+
+func (m *MPV) TogglePause() error {
+	return m.command("cycle", "pause")
+}
+
+func (m *MPV) Seek(offset time.Duration) error {
+	return m.command("seek", offset.Seconds(), "relative")
+}
+
+func (m *MPV) AdjustVolume(delta float64) error {
+	return m.command("add", "volume", delta)
+}
+
+func (m *MPV) command(args ...interface{}) error {
+	m.mu.Lock()
+	process := m.current
+	m.mu.Unlock()
+
+	if process == nil {
+		return ErrNotPlaying
+	}
+	return process.command(args...)
+}
+
+// mpvProcess is a single running mpv instance.
+type mpvProcess struct {
+	cmd    *exec.Cmd
+	conn   net.Conn
+	socket string
+	events chan interface{}
+
+	writeMu sync.Mutex
+
+	mu        sync.Mutex
+	position  time.Duration
+	duration  time.Duration
+	audio     int
+	subtitle  int
+	endReason string
+}
+
+// startMPV launches mpv for url and connects to its IPC socket.
+func startMPV(url string, opts Options) (*mpvProcess, error) {
+	socket := filepath.Join(os.TempDir(), fmt.Sprintf("jellyfin-tui-mpv-%d-%d.sock", os.Getpid(), time.Now().UnixNano()))
+
+	args := []string{
+		url,
+		"--input-ipc-server=" + socket,
+		// The TUI owns the terminal; mpv must not read keys from it or draw
+		// its status line over the interface.
+		"--no-terminal",
+	}
+	if opts.Title != "" {
+		args = append(args, "--force-media-title="+opts.Title)
+	}
+	if opts.Start > 0 {
+		args = append(args, fmt.Sprintf("--start=%d", int(opts.Start/time.Second)))
+	}
+	if opts.AudioTrack > 0 {
+		args = append(args, fmt.Sprintf("--aid=%d", opts.AudioTrack))
+	}
+	if opts.SubtitleFile != "" {
+		args = append(args, "--sub-file="+opts.SubtitleFile)
+	}
+	switch {
+	case opts.SubtitleTrack > 0:
+		args = append(args, fmt.Sprintf("--sid=%d", opts.SubtitleTrack))
+	case opts.SubtitleTrack < 0:
+		args = append(args, "--sid=no")
+	}
+	if opts.AudioOnly {
+		// Keep mpv from opening a window just to show embedded cover art.
+		args = append(args, "--no-video")
+	} else {
+		args = append(args, "--force-window=immediate")
+	}
+
+	cmd := exec.Command("mpv", args...)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	conn, err := dial("unix", socket, "mpv", exited)
+	if err != nil {
+		cmd.Process.Kill()
+		os.Remove(socket)
+		return nil, err
+	}
+
+	p := &mpvProcess{
+		cmd:    cmd,
+		conn:   conn,
+		socket: socket,
+		events: make(chan interface{}, eventBufferSize),
+	}
+
+	for id, property := range map[int]string{
+		observeTimePos:       "time-pos",
+		observeDuration:      "duration",
+		observePause:         "pause",
+		observeVolume:        "volume",
+		observeAudioTrack:    "aid",
+		observeSubtitleTrack: "sid",
+	} {
+		if err := p.command("observe_property", id, property); err != nil {
+			cmd.Process.Kill()
+			conn.Close()
+			os.Remove(socket)
+			return nil, err
+		}
+	}
+
+	readDone := make(chan struct{})
+	go func() {
+		p.readEvents()
+		close(readDone)
+	}()
+	go func() {
+		err := <-exited
+		conn.Close()
+		<-readDone
+		os.Remove(socket)
+
+		p.mu.Lock()
+		finished := p.endReason == "eof"
+		p.mu.Unlock()
+
+		p.events <- EndEvent{Finished: finished, Err: err}
+		close(p.events)
+	}()
+
+	return p, nil
+}
+
+func (p *mpvProcess) command(args ...interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"command": args})
+	if err != nil {
+		return err
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	_, err = p.conn.Write(append(payload, '\n'))
+	return err
+}
+
+type ipcMessage struct {
+	Event  string          `json:"event"`
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Data   json.RawMessage `json:"data"`
+	Reason string          `json:"reason"`
+}
+
+func (p *mpvProcess) readEvents() {
+	scanner := bufio.NewScanner(p.conn)
+	for scanner.Scan() {
+		var msg ipcMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+
+		switch msg.Event {
+		case "property-change":
+			if event, droppable, ok := p.propertyChanged(msg); ok {
+				p.send(event, droppable)
+			}
+		case "end-file":
+			p.mu.Lock()
+			p.endReason = msg.Reason
+			p.mu.Unlock()
+		}
+	}
+}
+
+// propertyChanged records an observed property and returns the event to
+// send for it, if any.
+func (p *mpvProcess) propertyChanged(msg ipcMessage) (event interface{}, droppable bool, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch msg.ID {
+	case observeTimePos, observeDuration:
+		var seconds float64
+		if json.Unmarshal(msg.Data, &seconds) != nil {
+			return nil, false, false
+		}
+		value := time.Duration(seconds * float64(time.Second))
+
+		if msg.ID == observeDuration {
+			p.duration = value
+		} else {
+			// time-pos changes every frame; only wake the UI when the
+			// displayed second changes.
+			previous := p.position
+			p.position = value
+			if math.Floor(previous.Seconds()) == math.Floor(value.Seconds()) {
+				return nil, false, false
+			}
+		}
+		return PositionEvent{Position: p.position, Duration: p.duration}, true, true
+	case observePause:
+		var paused bool
+		if json.Unmarshal(msg.Data, &paused) != nil {
+			return nil, false, false
+		}
+		return PauseEvent{Paused: paused}, false, true
+	case observeVolume:
+		var volume float64
+		if json.Unmarshal(msg.Data, &volume) != nil {
+			return nil, false, false
+		}
+		return VolumeEvent{Volume: volume}, false, true
+	case observeAudioTrack, observeSubtitleTrack:
+		// aid and sid are a track number, or false/"no" when disabled.
+		var id int
+		json.Unmarshal(msg.Data, &id)
+		if msg.ID == observeAudioTrack {
+			p.audio = id
+		} else {
+			p.subtitle = id
+		}
+		return TrackEvent{Audio: p.audio, Subtitle: p.subtitle}, false, true
+	}
+
+	return nil, false, false
+}
+
+// send delivers an event to the UI. Position updates are dropped rather than
+// blocking when the UI falls behind, since the next one supersedes them.
+func (p *mpvProcess) send(event interface{}, droppable bool) {
+	if !droppable {
+		p.events <- event
+		return
+	}
+	select {
+	case p.events <- event:
+	default:
+	}
+}
@@ -0,0 +1,133 @@
+// Package player runs external media players and reports their progress.
+package player
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"time"
+)
+
+// Player plays one stream at a time in an external program.
+type Player interface {
+	// Play starts url, stopping whatever the player was playing before. It
+	// returns the new playback's event channel, which is closed after the
+	// final EndEvent.
+	Play(url string, opts Options) (<-chan interface{}, error)
+	Stop() error
+}
+
+// Controller is implemented by players that can be driven while playing.
+type Controller interface {
+	TogglePause() error
+	// Seek moves the playback position by offset, which may be negative.
+	Seek(offset time.Duration) error
+	// AdjustVolume changes the volume by delta percentage points.
+	AdjustVolume(delta float64) error
+}
+
+// Options controls how a stream is played.
+type Options struct {
+	Title     string
+	Start     time.Duration
+	AudioOnly bool
+
+	// AudioTrack and SubtitleTrack select tracks numbered from 1 among the
+	// stream's tracks of that kind, with SubtitleFile, if any, counted
+	// after the embedded subtitles. Zero keeps the player's default and a
+	// SubtitleTrack of -1 disables subtitles.
+	AudioTrack    int
+	SubtitleTrack int
+	SubtitleFile  string
+}
+
+// Events are delivered on a player's event channel while it runs.
+type (
+	PositionEvent struct {
+		Position time.Duration
+		Duration time.Duration
+	}
+
+	PauseEvent struct {
+		Paused bool
+	}
+
+	VolumeEvent struct {
+		Volume float64
+	}
+
+	// TrackEvent reports the active audio and subtitle track IDs as mpv
+	// numbers them; zero means none.
+	TrackEvent struct {
+		Audio    int
+		Subtitle int
+	}
+
+	// EndEvent is sent once the player exits. Finished is true when the
+	// media played to the end rather than being stopped.
+	EndEvent struct {
+		Finished bool
+		Err      error
+	}
+)
+
+var ErrNotPlaying = errors.New("nothing is playing")
+
+const (
+	connectTimeout  = 5 * time.Second
+	eventBufferSize = 64
+)
+
+// New returns the player named in the config: "mpv" (the default), "vlc", or
+// "command" to run commandTemplate. It fails if the player is not installed.
+func New(name, commandTemplate string) (Player, error) {
+	switch name {
+	case "", "mpv":
+		if _, err := exec.LookPath("mpv"); err != nil {
+			return nil, fmt.Errorf("MPV is not installed or not in PATH. Please install MPV to use this application")
+		}
+		return NewMPV(), nil
+	case "vlc":
+		if _, err := exec.LookPath("vlc"); err != nil {
+			return nil, fmt.Errorf("VLC is not installed or not in PATH. Please install VLC or choose another player")
+		}
+		return NewVLC(), nil
+	case "command":
+		command, err := NewCommand(commandTemplate)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := exec.LookPath(command.program()); err != nil {
+			return nil, fmt.Errorf("player command %q is not installed or not in PATH", command.program())
+		}
+		return command, nil
+	default:
+		return nil, fmt.Errorf("unknown player %q; use mpv, vlc or command", name)
+	}
+}
+
+// dial connects to a control socket the player named name is about to open,
+// giving up if the player exits first or takes too long to start.
+func dial(network, address, name string, exited <-chan error) (net.Conn, error) {
+	deadline := time.Now().Add(connectTimeout)
+	for {
+		conn, err := net.Dial(network, address)
+		if err == nil {
+			return conn, nil
+		}
+
+		select {
+		case exitErr := <-exited:
+			if exitErr == nil {
+				exitErr = fmt.Errorf("exited immediately")
+			}
+			return nil, fmt.Errorf("%s failed to start: %v", name, exitErr)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out connecting to %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package player
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	vlcPollInterval = time.Second
+	vlcQueryTimeout = 2 * time.Second
+
+	// vlcFullVolume is 100% on the RC interface's volume scale.
+	vlcFullVolume = 256
+)
+
+// VLC plays media in VLC, controlling it through the line-based RC interface
+// served on a local TCP port.
+type VLC struct {
+	mu      sync.Mutex
+	current *vlcProcess
+}
+
+func NewVLC() *VLC {
+	return &VLC{}
+}
+
+// Play launches VLC for url, shutting down any earlier instance.
+func (v *VLC) Play(url string, opts Options) (<-chan interface{}, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.current != nil {
+		v.current.stop()
+	}
+
+	process, err := startVLC(url, opts)
+	if err != nil {
+		v.current = nil
+		return nil, err
+	}
+	v.current = process
+	return process.events, nil
+}
+
+func (v *VLC) Stop() error {
+	process, err := v.process()
+	if err != nil {
+		return err
+	}
+	return process.stop()
+}
+
+func (v *VLC) TogglePause() error {
+	process, err := v.process()
+	if err != nil {
+		return err
+	}
+	return process.togglePause()
+}
+
+func (v *VLC) Seek(offset time.Duration) error {
+	process, err := v.process()
+	if err != nil {
+		return err
+	}
+
+	// RC only seeks to absolute positions.
+	seconds, err := process.query("get_time")
+	if err != nil {
+		return err
+	}
+	target := seconds + offset.Seconds()
+	if target < 0 {
+		target = 0
+	}
+	return process.send(fmt.Sprintf("seek %d", int(target)))
+}
+
+func (v *VLC) AdjustVolume(delta float64) error {
+	process, err := v.process()
+	if err != nil {
+		return err
+	}
+
+	volume, err := process.query("volume")
+	if err != nil {
+		return err
+	}
+	volume += delta * vlcFullVolume / 100
+	if volume < 0 {
+		volume = 0
+	}
+	if err := process.send(fmt.Sprintf("volume %d", int(volume))); err != nil {
+		return err
+	}
+	process.emit(VolumeEvent{Volume: volume * 100 / vlcFullVolume}, false)
+	return nil
+}
+
+func (v *VLC) process() (*vlcProcess, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.current == nil {
+		return nil, ErrNotPlaying
+	}
+	return v.current, nil
+}
+
+// vlcProcess is a single running VLC instance.
+type vlcProcess struct {
+	cmd    *exec.Cmd
+	conn   net.Conn
+	reader *bufio.Reader
+	events chan interface{}
+	done   chan struct{}
+
+	// mu serialises commands on the connection, since RC answers them in
+	// order with no request IDs.
+	mu      sync.Mutex
+	paused  bool
+	stopped bool
+
+	// eventsMu guards closing events against sends from control methods.
+	eventsMu sync.Mutex
+	closed   bool
+}
+
+func startVLC(url string, opts Options) (*vlcProcess, error) {
+	address, err := freeLocalAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		url,
+		"--intf", "dummy",
+		"--extraintf", "rc",
+		"--rc-host", address,
+		"--play-and-exit",
+	}
+	if opts.Title != "" {
+		args = append(args, "--meta-title="+opts.Title)
+	}
+	if opts.Start > 0 {
+		args = append(args, fmt.Sprintf("--start-time=%d", int(opts.Start/time.Second)))
+	}
+	if opts.AudioOnly {
+		args = append(args, "--no-video")
+	}
+	// VLC numbers tracks from 0.
+	if opts.AudioTrack > 0 {
+		args = append(args, fmt.Sprintf("--audio-track=%d", opts.AudioTrack-1))
+	}
+	if opts.SubtitleFile != "" {
+		args = append(args, "--sub-file="+opts.SubtitleFile)
+	}
+	switch {
+	case opts.SubtitleTrack > 0:
+		args = append(args, fmt.Sprintf("--sub-track=%d", opts.SubtitleTrack-1))
+	case opts.SubtitleTrack < 0:
+		args = append(args, "--no-spu")
+	}
+
+	cmd := exec.Command("vlc", args...)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	conn, err := dial("tcp", address, "VLC", exited)
+	if err != nil {
+		cmd.Process.Kill()
+		return nil, err
+	}
+
+	p := &vlcProcess{
+		cmd:    cmd,
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+		events: make(chan interface{}, eventBufferSize),
+		done:   make(chan struct{}),
+	}
+
+	go p.poll()
+	go func() {
+		err := <-exited
+		conn.Close()
+		close(p.done)
+
+		p.mu.Lock()
+		finished := !p.stopped && err == nil
+		p.mu.Unlock()
+
+		p.eventsMu.Lock()
+		p.events <- EndEvent{Finished: finished, Err: err}
+		close(p.events)
+		p.closed = true
+		p.eventsMu.Unlock()
+	}()
+
+	return p, nil
+}
+
+// freeLocalAddress picks a loopback port for the RC interface to listen on.
+func freeLocalAddress() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer listener.Close()
+	return listener.Addr().String(), nil
+}
+
+// poll reports the playback position, which RC only gives on request.
+func (p *vlcProcess) poll() {
+	ticker := time.NewTicker(vlcPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+
+		position, err := p.query("get_time")
+		if err != nil {
+			continue
+		}
+		length, err := p.query("get_length")
+		if err != nil {
+			continue
+		}
+
+		p.emit(PositionEvent{
+			Position: time.Duration(position * float64(time.Second)),
+			Duration: time.Duration(length * float64(time.Second)),
+		}, true)
+	}
+}
+
+// emit delivers an event unless VLC has already exited. Droppable events are
+// discarded rather than blocking when the UI falls behind.
+func (p *vlcProcess) emit(event interface{}, droppable bool) {
+	p.eventsMu.Lock()
+	defer p.eventsMu.Unlock()
+
+	if p.closed {
+		return
+	}
+	if !droppable {
+		p.events <- event
+		return
+	}
+	select {
+	case p.events <- event:
+	default:
+	}
+}
+
+func (p *vlcProcess) togglePause() error {
+	if err := p.send("pause"); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.paused = !p.paused
+	paused := p.paused
+	p.mu.Unlock()
+
+	p.emit(PauseEvent{Paused: paused}, false)
+	return nil
+}
+
+func (p *vlcProcess) stop() error {
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+
+	return p.send("shutdown")
+}
+
+func (p *vlcProcess) send(command string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err := p.conn.Write([]byte(command + "\n"))
+	return err
+}
+
+// query sends command and returns the number it answers with, skipping the
+// banner, prompts and status lines RC interleaves with its answers.
+func (p *vlcProcess) query(command string) (float64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.conn.Write([]byte(command + "\n")); err != nil {
+		return 0, err
+	}
+
+	p.conn.SetReadDeadline(time.Now().Add(vlcQueryTimeout))
+	defer p.conn.SetReadDeadline(time.Time{})
+
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		line = strings.TrimSpace(strings.TrimLeft(line, "> "))
+		if value, err := strconv.ParseFloat(line, 64); err == nil {
+			return value, nil
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Package queue holds the list of items to play and decides what plays next.
+package queue
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+)
+
+type RepeatMode int
+
+const (
+	RepeatOff RepeatMode = iota
+	RepeatOne
+	RepeatAll
+)
+
+func (r RepeatMode) String() string {
+	switch r {
+	case RepeatOne:
+		return "one"
+	case RepeatAll:
+		return "all"
+	default:
+		return "off"
+	}
+}
+
+// Entry is an item in the queue. seq records the order it was added in, so
+// turning shuffle off can restore that order.
+type Entry struct {
+	Item jellyfin.MediaItem
+	seq  int
+}
+
+// Queue is an ordered list of entries with a cursor on the one playing.
+// Shuffling reorders the entries still to come, so the list always shows the
+// actual play order.
+type Queue struct {
+	entries []Entry
+	current int
+	nextSeq int
+	shuffle bool
+	repeat  RepeatMode
+	rand    *rand.Rand
+}
+
+func New() *Queue {
+	return &Queue{
+		current: -1,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (q *Queue) Entries() []Entry {
+	return q.entries
+}
+
+func (q *Queue) Len() int {
+	return len(q.entries)
+}
+
+// Index returns the position of the current entry, or -1 if there is none.
+func (q *Queue) Index() int {
+	return q.current
+}
+
+func (q *Queue) Current() (jellyfin.MediaItem, bool) {
+	if q.current < 0 || q.current >= len(q.entries) {
+		return jellyfin.MediaItem{}, false
+	}
+	return q.entries[q.current].Item, true
+}
+
+// Upcoming returns the entry that Next would move to, if any.
+func (q *Queue) Upcoming() (jellyfin.MediaItem, bool) {
+	if q.current+1 < len(q.entries) {
+		return q.entries[q.current+1].Item, true
+	}
+	if q.repeat == RepeatAll && len(q.entries) > 0 && !q.shuffle {
+		return q.entries[0].Item, true
+	}
+	return jellyfin.MediaItem{}, false
+}
+
+func (q *Queue) Shuffle() bool {
+	return q.shuffle
+}
+
+func (q *Queue) Repeat() RepeatMode {
+	return q.repeat
+}
+
+// Replace empties the queue, fills it with items and makes items[index]
+// current.
+func (q *Queue) Replace(items []jellyfin.MediaItem, index int) {
+	q.entries = nil
+	q.current = -1
+	q.Add(items...)
+	if index >= 0 && index < len(q.entries) {
+		q.current = index
+	}
+	if q.shuffle {
+		q.shuffleUpcoming()
+	}
+}
+
+// Add appends items to the end of the queue.
+func (q *Queue) Add(items ...jellyfin.MediaItem) {
+	for _, item := range items {
+		q.entries = append(q.entries, Entry{Item: item, seq: q.nextSeq})
+		q.nextSeq++
+	}
+}
+
+func (q *Queue) Clear() {
+	q.entries = nil
+	q.current = -1
+}
+
+// Advance moves to the entry to play after the current one finishes,
+// honouring the repeat mode. It returns false when the queue is done.
+func (q *Queue) Advance() (jellyfin.MediaItem, bool) {
+	if q.repeat == RepeatOne {
+		return q.Current()
+	}
+	return q.Next()
+}
+
+// Next skips to the following entry, wrapping around under repeat-all.
+func (q *Queue) Next() (jellyfin.MediaItem, bool) {
+	if len(q.entries) == 0 {
+		return jellyfin.MediaItem{}, false
+	}
+	if q.current+1 < len(q.entries) {
+		q.current++
+		return q.Current()
+	}
+	if q.repeat != RepeatAll {
+		// Stay on the last entry, so anything added later plays next.
+		return jellyfin.MediaItem{}, false
+	}
+
+	q.current = 0
+	if q.shuffle {
+		q.current = -1
+		q.shuffleUpcoming()
+		q.current = 0
+	}
+	return q.Current()
+}
+
+// Previous steps back one entry, wrapping around under repeat-all.
+func (q *Queue) Previous() (jellyfin.MediaItem, bool) {
+	if len(q.entries) == 0 {
+		return jellyfin.MediaItem{}, false
+	}
+	switch {
+	case q.current > 0:
+		q.current--
+	case q.repeat == RepeatAll:
+		q.current = len(q.entries) - 1
+	default:
+		q.current = 0
+	}
+	return q.Current()
+}
+
+// Jump makes the entry at index current.
+func (q *Queue) Jump(index int) (jellyfin.MediaItem, bool) {
+	if index < 0 || index >= len(q.entries) {
+		return jellyfin.MediaItem{}, false
+	}
+	q.current = index
+	return q.Current()
+}
+
+// Remove drops the entry at index and reports whether there was one. The
+// caller must not remove the current entry while it plays. Once it is gone
+// the entry that followed it plays next.
+func (q *Queue) Remove(index int) bool {
+	if index < 0 || index >= len(q.entries) {
+		return false
+	}
+	q.entries = append(q.entries[:index], q.entries[index+1:]...)
+	if index <= q.current {
+		q.current--
+	}
+	return true
+}
+
+// Move swaps the entry at index with its neighbour delta places away,
+// keeping the cursor on the same entry.
+func (q *Queue) Move(index, delta int) bool {
+	target := index + delta
+	if index < 0 || index >= len(q.entries) || target < 0 || target >= len(q.entries) {
+		return false
+	}
+	q.entries[index], q.entries[target] = q.entries[target], q.entries[index]
+	switch q.current {
+	case index:
+		q.current = target
+	case target:
+		q.current = index
+	}
+	return true
+}
+
+// ToggleShuffle shuffles the entries after the current one, or puts them
+// back in the order they were added.
+func (q *Queue) ToggleShuffle() {
+	q.shuffle = !q.shuffle
+	if q.shuffle {
+		q.shuffleUpcoming()
+		return
+	}
+
+	upcoming := q.entries[q.current+1:]
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].seq < upcoming[j].seq
+	})
+}
+
+// CycleRepeat switches between repeat off, one and all.
+func (q *Queue) CycleRepeat() RepeatMode {
+	q.repeat = (q.repeat + 1) % 3
+	return q.repeat
+}
+
+func (q *Queue) shuffleUpcoming() {
+	upcoming := q.entries[q.current+1:]
+	q.rand.Shuffle(len(upcoming), func(i, j int) {
+		upcoming[i], upcoming[j] = upcoming[j], upcoming[i]
+	})
+}
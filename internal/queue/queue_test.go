@@ -0,0 +1,177 @@
+package queue
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+)
+
+func newTestQueue(ids []string, current int) *Queue {
+	q := New()
+	q.rand = rand.New(rand.NewSource(1))
+	items := make([]jellyfin.MediaItem, len(ids))
+	for i, id := range ids {
+		items[i] = jellyfin.MediaItem{ID: id}
+	}
+	q.Replace(items, current)
+	return q
+}
+
+func ids(q *Queue) []string {
+	ids := []string{}
+	for _, entry := range q.Entries() {
+		ids = append(ids, entry.Item.ID)
+	}
+	return ids
+}
+
+func currentID(q *Queue) string {
+	item, ok := q.Current()
+	if !ok {
+		return ""
+	}
+	return item.ID
+}
+
+func TestNavigation(t *testing.T) {
+	tests := []struct {
+		name    string
+		current int
+		repeat  RepeatMode
+		step    func(*Queue) (jellyfin.MediaItem, bool)
+		want    string
+		wantOK  bool
+	}{
+		{"next", 0, RepeatOff, (*Queue).Next, "b", true},
+		{"next at end", 2, RepeatOff, (*Queue).Next, "c", false},
+		{"next at end repeating all", 2, RepeatAll, (*Queue).Next, "a", true},
+		{"previous", 1, RepeatOff, (*Queue).Previous, "a", true},
+		{"previous at start", 0, RepeatOff, (*Queue).Previous, "a", true},
+		{"previous at start repeating all", 0, RepeatAll, (*Queue).Previous, "c", true},
+		{"advance", 0, RepeatOff, (*Queue).Advance, "b", true},
+		{"advance repeating one", 1, RepeatOne, (*Queue).Advance, "b", true},
+		{"advance at end", 2, RepeatOff, (*Queue).Advance, "c", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueue([]string{"a", "b", "c"}, tt.current)
+			for q.Repeat() != tt.repeat {
+				q.CycleRepeat()
+			}
+
+			item, ok := tt.step(q)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && item.ID != tt.want {
+				t.Errorf("returned %q, want %q", item.ID, tt.want)
+			}
+			if got := currentID(q); got != tt.want {
+				t.Errorf("current = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCycleRepeat(t *testing.T) {
+	q := New()
+	for _, want := range []RepeatMode{RepeatOne, RepeatAll, RepeatOff} {
+		if got := q.CycleRepeat(); got != want {
+			t.Fatalf("CycleRepeat() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	order := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	q := newTestQueue(order, 2)
+
+	q.ToggleShuffle()
+	shuffled := ids(q)
+	if !reflect.DeepEqual(shuffled[:3], order[:3]) {
+		t.Errorf("shuffle moved played entries: %v", shuffled)
+	}
+	if reflect.DeepEqual(shuffled, order) {
+		t.Errorf("shuffle kept the order %v", shuffled)
+	}
+	if got := currentID(q); got != "c" {
+		t.Errorf("current = %q after shuffling, want %q", got, "c")
+	}
+
+	q.ToggleShuffle()
+	if got := ids(q); !reflect.DeepEqual(got, order) {
+		t.Errorf("unshuffled order = %v, want %v", got, order)
+	}
+}
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name        string
+		index       int
+		delta       int
+		wantOK      bool
+		wantOrder   []string
+		wantCurrent string
+	}{
+		{"down", 0, 1, true, []string{"b", "a", "c"}, "b"},
+		{"up", 2, -1, true, []string{"a", "c", "b"}, "b"},
+		{"the current entry", 1, 1, true, []string{"a", "c", "b"}, "b"},
+		{"past the start", 0, -1, false, []string{"a", "b", "c"}, "b"},
+		{"past the end", 2, 1, false, []string{"a", "b", "c"}, "b"},
+		{"out of range", 5, -1, false, []string{"a", "b", "c"}, "b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueue([]string{"a", "b", "c"}, 1)
+			if ok := q.Move(tt.index, tt.delta); ok != tt.wantOK {
+				t.Errorf("Move(%d, %d) = %v, want %v", tt.index, tt.delta, ok, tt.wantOK)
+			}
+			if got := ids(q); !reflect.DeepEqual(got, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", got, tt.wantOrder)
+			}
+			if got := currentID(q); got != tt.wantCurrent {
+				t.Errorf("current = %q, want %q", got, tt.wantCurrent)
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   int
+		index     int
+		wantOK    bool
+		wantOrder []string
+		// wantNext is what Next plays after the removal.
+		wantNext string
+	}{
+		{"before the current entry", 2, 0, true, []string{"b", "c", "d"}, "d"},
+		{"after the current entry", 1, 2, true, []string{"a", "b", "d"}, "d"},
+		{"the current entry", 1, 1, true, []string{"a", "c", "d"}, "c"},
+		{"the first entry while current", 0, 0, true, []string{"b", "c", "d"}, "b"},
+		{"the last entry while current", 3, 3, true, []string{"a", "b", "c"}, ""},
+		{"out of range", 1, 4, false, []string{"a", "b", "c", "d"}, "c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueue([]string{"a", "b", "c", "d"}, tt.current)
+			if ok := q.Remove(tt.index); ok != tt.wantOK {
+				t.Errorf("Remove(%d) = %v, want %v", tt.index, ok, tt.wantOK)
+			}
+			if got := ids(q); !reflect.DeepEqual(got, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", got, tt.wantOrder)
+			}
+
+			next, _ := q.Next()
+			if next.ID != tt.wantNext {
+				t.Errorf("Next() = %q, want %q", next.ID, tt.wantNext)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ var (
 	titleStyle        = lipgloss.NewStyle().MarginLeft(2)
 	itemStyle         = lipgloss.NewStyle().PaddingLeft(4)
 	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("170"))
+	breadcrumbStyle   = lipgloss.NewStyle().MarginLeft(2).Foreground(lipgloss.Color("240"))
 )
 
 type browseModel struct {
@@ -24,6 +25,18 @@ type browseModel struct {
 	page         int
 	itemsPerPage int
 	totalItems   int
+
+	// path is the breadcrumb trail from the library views down to the
+	// folder currently shown; empty means the views themselves.
+	path []browseLevel
+}
+
+// browseLevel is a folder we descended into, along with the page and cursor
+// of the listing it was opened from so going back restores them.
+type browseLevel struct {
+	folder jellyfin.MediaItem
+	page   int
+	cursor int
 }
 
 func newBrowseModel(client *jellyfin.Client) browseModel {
@@ -51,7 +64,15 @@ func (m browseModel) Update(msg tea.Msg) (browseModel, tea.Cmd) {
 			if m.cursor < len(m.items)-1 {
 				m.cursor++
 			}
-		case "enter", " ":
+		case "enter", "right":
+			if len(m.items) > 0 {
+				return m.open(m.items[m.cursor])
+			}
+		case "backspace", "left":
+			if len(m.path) > 0 {
+				return m.up()
+			}
+		case " ":
 			_, ok := m.selected[m.cursor]
 			if ok {
 				delete(m.selected, m.cursor)
@@ -68,23 +89,107 @@ func (m browseModel) Update(msg tea.Msg) (browseModel, tea.Cmd) {
 				m.page--
 				return m, m.fetchItems
 			}
+		case "a":
+			if len(m.items) > 0 {
+				return m, enqueueCmd(m.client, m.items[m.cursor])
+			}
 		case "f":
 			return m, m.showFilter
 		case "s":
 			return m, m.showSearch
-		case "q", "esc":
-			return m, m.quit
+		case "u":
+			return m, m.showUsers
+		case "S":
+			return m, m.showSettings
+		case "L":
+			return m, m.logout
+		case "esc":
+			if len(m.path) > 0 {
+				return m.up()
+			}
+			return m, m.back
 		}
 	case mediaItemsMsg:
+		if msg.parentID != m.parentID() || msg.filter != m.filter {
+			// A response for a folder we already navigated away from.
+			return m, nil
+		}
 		m.items = msg.items
 		m.totalItems = msg.totalItems
+		if m.cursor >= len(m.items) {
+			m.cursor = 0
+		}
 	}
 
 	return m, nil
 }
 
+func (m browseModel) open(item jellyfin.MediaItem) (browseModel, tea.Cmd) {
+	if item.CollectionType == "music" {
+		return m, func() tea.Msg { return showMusicMsg{library: item} }
+	}
+	if item.Type == "Series" {
+		return m, func() tea.Msg { return showSeriesMsg{series: item} }
+	}
+	if !item.IsFolder {
+		return m, func() tea.Msg { return showDetailMsg{item: item} }
+	}
+
+	m.path = append(m.path[:len(m.path):len(m.path)], browseLevel{
+		folder: item,
+		page:   m.page,
+		cursor: m.cursor,
+	})
+	m.page = 1
+	m.cursor = 0
+	m.items = nil
+	m.selected = make(map[int]struct{})
+	return m, m.fetchItems
+}
+
+// setFilter lists every item of itemType across the libraries, or the
+// library views again when itemType is empty.
+func (m browseModel) setFilter(itemType string) (browseModel, tea.Cmd) {
+	m.filter = itemType
+	m.path = nil
+	m.page = 1
+	m.cursor = 0
+	m.items = nil
+	m.selected = make(map[int]struct{})
+	return m, m.fetchItems
+}
+
+func (m browseModel) up() (browseModel, tea.Cmd) {
+	level := m.path[len(m.path)-1]
+	m.path = m.path[:len(m.path)-1]
+	m.page = level.page
+	m.cursor = level.cursor
+	m.items = nil
+	m.selected = make(map[int]struct{})
+	return m, m.fetchItems
+}
+
+func (m browseModel) parentID() string {
+	if len(m.path) == 0 {
+		return ""
+	}
+	return m.path[len(m.path)-1].folder.ID
+}
+
+func (m browseModel) breadcrumb() string {
+	crumbs := []string{"Libraries"}
+	if m.filter != "" {
+		crumbs[0] = "All " + m.filter
+	}
+	for _, level := range m.path {
+		crumbs = append(crumbs, level.folder.Name)
+	}
+	return strings.Join(crumbs, " > ")
+}
+
 func (m browseModel) View() string {
-	s := titleStyle.Render("Browse Media Items") + "\n\n"
+	s := titleStyle.Render("Browse Media Items") + "\n"
+	s += breadcrumbStyle.Render(m.breadcrumb()) + "\n\n"
 
 	for i, item := range m.items {
 		cursor := " "
@@ -102,22 +207,42 @@ func (m browseModel) View() string {
 			style = selectedItemStyle
 		}
 
-		s += style.Render(fmt.Sprintf("%s [%s] %s\n", cursor, checked, item.Name))
+		name := item.Name
+		if item.IsFolder {
+			name += "/"
+		}
+
+		s += style.Render(fmt.Sprintf("%s [%s] %s\n", cursor, checked, name))
 	}
 
 	s += fmt.Sprintf("\nPage %d of %d", m.page, (m.totalItems+m.itemsPerPage-1)/m.itemsPerPage)
-	s += "\n\nPress 'f' to filter, 's' to search, 'n' for next page, 'p' for previous page"
-	s += "\nPress 'q' to quit"
+	s += "\n\nPress Enter to open, Backspace to go up, Esc to go home, space to select, 'a' to add to the queue"
+	s += "\nPress 'f' to filter, 's' to search, 'n' for next page, 'p' for previous page"
+	s += "\nPress 'u' to switch user, 'S' for settings, 'L' to log out"
 
 	return s
 }
 
 func (m browseModel) fetchItems() tea.Msg {
-	items, total, err := m.client.GetMediaItems(m.page, m.itemsPerPage, m.filter)
+	parentID := m.parentID()
+
+	if parentID == "" && m.filter == "" {
+		views, err := m.client.GetViews()
+		if err != nil {
+			return errorMsg{err}
+		}
+		return mediaItemsMsg{items: views, totalItems: len(views)}
+	}
+
+	items, total, err := m.client.GetMediaItems(parentID, m.page, m.itemsPerPage, m.filter)
 	if err != nil {
 		return errorMsg{err}
 	}
-	return mediaItemsMsg{items: items, totalItems: total}
+	return mediaItemsMsg{parentID: parentID, filter: m.filter, items: items, totalItems: total}
+}
+
+func (m browseModel) back() tea.Msg {
+	return backMsg{}
 }
 
 func (m browseModel) showFilter() tea.Msg {
@@ -128,15 +253,24 @@ func (m browseModel) showSearch() tea.Msg {
 	return showSearchMsg{}
 }
 
-func (m browseModel) quit() tea.Msg {
-	return quitMsg{}
+func (m browseModel) showUsers() tea.Msg {
+	return showUsersMsg{}
+}
+
+func (m browseModel) showSettings() tea.Msg {
+	return showSettingsMsg{}
+}
+
+func (m browseModel) logout() tea.Msg {
+	return logoutMsg{}
 }
 
 type mediaItemsMsg struct {
+	parentID   string
+	filter     string
 	items      []jellyfin.MediaItem
 	totalItems int
 }
 
 type showFilterMsg struct{}
 type showSearchMsg struct{}
-type quitMsg struct{}
@@ -2,10 +2,12 @@ package ui
 
 import (
 	"fmt"
-	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/artwork"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/config"
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,26 +19,85 @@ var (
 				Background(lipgloss.Color("#7D56F4")).
 				Padding(0, 1)
 
-	detailInfoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			MarginTop(1)
+	detailFactsStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("241"))
+
+	detailTaglineStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				Italic(true)
+
+	detailLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("241"))
+
+	detailValueStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA"))
+
+	detailOverviewStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				Width(80)
 
 	detailActionStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#7D56F4")).
 				MarginTop(1)
+
+	detailSectionStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				Bold(true)
+
+	detailTrackStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				PaddingLeft(2)
+
+	detailSelectedTrackStyle = lipgloss.NewStyle().
+					Foreground(lipgloss.Color("205")).
+					PaddingLeft(2)
+)
+
+// maxCastShown limits the cast list so the actions stay on screen.
+const maxCastShown = 8
+
+// The poster is fitted into this many cells above the title.
+const (
+	posterCols = 32
+	posterRows = 12
 )
 
 type detailModel struct {
-	item   *jellyfin.MediaItem
-	client *jellyfin.Client
+	item    *jellyfin.MediaItem
+	client  *jellyfin.Client
+	config  *config.Config
+	artwork *artwork.Store
+
+	// poster is the rendered primary image, once loaded.
+	poster string
+
+	// tracks is the audio and subtitle choice for the item's default media
+	// source, starting from the user's language preferences.
+	tracks jellyfin.TrackSelection
 }
 
-func newDetailModel(client *jellyfin.Client) detailModel {
+func newDetailModel(client *jellyfin.Client, cfg *config.Config, images *artwork.Store) detailModel {
 	return detailModel{
-		client: client,
+		client:  client,
+		config:  cfg,
+		artwork: images,
 	}
 }
 
+// show displays item straight away; the caller should run refresh to load
+// the media sources that listings leave out.
+func (m detailModel) show(item jellyfin.MediaItem) detailModel {
+	if m.item == nil || m.item.ID != item.ID {
+		m.poster = ""
+		m.tracks = jellyfin.TrackSelection{}
+	}
+	m.item = &item
+	if m.tracks.MediaSourceID == "" {
+		m.tracks = m.defaultTracks()
+	}
+	return m
+}
+
 func (m detailModel) Init() tea.Cmd {
 	return nil
 }
@@ -47,13 +108,44 @@ func (m detailModel) Update(msg tea.Msg) (detailModel, tea.Cmd) {
 		switch msg.String() {
 		case "enter":
 			return m, m.playMedia
+		case "s":
+			return m, m.playFromStart
 		case "p":
 			return m, m.addToPlaylist
-		case "esc", "q":
+		case "a":
+			if m.item != nil {
+				return m, enqueueCmd(m.client, *m.item)
+			}
+		case "t":
+			return m.cycleAudio()
+		case "c":
+			return m.cycleSubtitles()
+		case "esc":
 			return m, m.back
 		}
 	case jellyfin.MediaItem:
+		if m.item != nil && m.item.ID != msg.ID {
+			// Details for an item we already left.
+			return m, nil
+		}
 		m.item = &msg
+		// Keep the tracks the user picked; a refresh, e.g. after playback,
+		// is for the same item.
+		if m.tracks.MediaSourceID == "" {
+			m.tracks = m.defaultTracks()
+		}
+		if m.poster == "" {
+			return m, m.loadPoster
+		}
+	case posterMsg:
+		if m.item != nil && m.item.ID == msg.itemID {
+			m.poster = msg.poster
+		}
+	case playbackFinishedMsg:
+		// Pick up the new resume position and played state.
+		if m.item != nil && msg.item.ID == m.item.ID {
+			return m, m.refresh
+		}
 	}
 	return m, nil
 }
@@ -65,38 +157,432 @@ func (m detailModel) View() string {
 
 	var b strings.Builder
 
+	if m.poster != "" {
+		b.WriteString(m.poster)
+		b.WriteString("\n\n")
+	} else {
+		// Take down the previous item's image while this one loads.
+		b.WriteString(m.artwork.Clear())
+	}
+
 	b.WriteString(detailTitleStyle.Render(m.item.Name))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if facts := m.facts(); facts != "" {
+		b.WriteString(detailFactsStyle.Render(facts))
+		b.WriteString("\n")
+	}
+	if len(m.item.Taglines) > 0 {
+		b.WriteString(detailTaglineStyle.Render(m.item.Taglines[0]))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.infoLines())
 
-	b.WriteString(detailInfoStyle.Render(fmt.Sprintf("Type: %s\n", m.item.Type)))
 	if m.item.Overview != "" {
-		b.WriteString(detailInfoStyle.Render(fmt.Sprintf("Overview: %s\n", m.item.Overview)))
+		b.WriteString("\n")
+		b.WriteString(detailOverviewStyle.Render(m.item.Overview))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.peopleLines())
+	b.WriteString(m.mediaLines())
+	b.WriteString(m.trackLines())
+
+	b.WriteString("\n")
+	if position := m.item.UserData.PlaybackPosition(); position > 0 {
+		b.WriteString(detailActionStyle.Render(fmt.Sprintf("Press Enter to resume from %s", formatDuration(position))))
+		b.WriteString("\n")
+		b.WriteString(detailActionStyle.Render("Press 's' to play from start"))
+	} else {
+		b.WriteString(detailActionStyle.Render("Press Enter to play"))
+	}
+	b.WriteString("\n")
+	b.WriteString(detailActionStyle.Render("Press 'p' to add to playlist, 'a' to add to the queue"))
+	b.WriteString("\n")
+	b.WriteString(detailActionStyle.Render("Press 'q' or Esc to go back"))
+
+	return b.String()
+}
+
+// facts is the one-line summary under the title, e.g.
+// "Movie · 2019 · R · 2h 12m · ★ 7.8 · 91%".
+func (m detailModel) facts() string {
+	var parts []string
+	if m.item.Type != "" {
+		parts = append(parts, m.item.Type)
+	}
+	if m.item.ProductionYear > 0 {
+		parts = append(parts, strconv.Itoa(m.item.ProductionYear))
+	}
+	if m.item.OfficialRating != "" {
+		parts = append(parts, m.item.OfficialRating)
+	}
+	if m.item.RunTimeTicks > 0 {
+		parts = append(parts, formatRuntime(m.item.Runtime()))
 	}
 	if m.item.CommunityRating > 0 {
-		b.WriteString(detailInfoStyle.Render(fmt.Sprintf("Rating: %.1f\n", m.item.CommunityRating)))
+		parts = append(parts, fmt.Sprintf("★ %.1f", m.item.CommunityRating))
+	}
+	if m.item.CriticRating > 0 {
+		parts = append(parts, fmt.Sprintf("Critics %.0f%%", m.item.CriticRating))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (m detailModel) infoLines() string {
+	var studios []string
+	for _, studio := range m.item.Studios {
+		studios = append(studios, studio.Name)
+	}
+
+	var b strings.Builder
+	for _, field := range []struct{ label, value string }{
+		{"Genres", strings.Join(m.item.Genres, ", ")},
+		{"Studios", strings.Join(studios, ", ")},
+		{"Premiered", formatDate(m.item.PremiereDate)},
+	} {
+		if field.value == "" {
+			continue
+		}
+		b.WriteString(detailLabelStyle.Render(field.label+":") + " " + detailValueStyle.Render(field.value))
+		b.WriteString("\n")
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return "\n" + b.String()
+}
+
+// peopleLines lists directors and writers by name and the leading cast with
+// the characters they play.
+func (m detailModel) peopleLines() string {
+	var directors, writers, cast []string
+	for _, person := range m.item.People {
+		switch person.Type {
+		case "Director":
+			directors = append(directors, person.Name)
+		case "Writer":
+			writers = append(writers, person.Name)
+		case "Actor", "GuestStar":
+			if len(cast) == maxCastShown {
+				continue
+			}
+			if person.Role != "" {
+				cast = append(cast, person.Name+" as "+person.Role)
+			} else {
+				cast = append(cast, person.Name)
+			}
+		}
+	}
+	if len(directors) == 0 && len(writers) == 0 && len(cast) == 0 {
+		return ""
 	}
 
+	var b strings.Builder
 	b.WriteString("\n")
-	b.WriteString(detailActionStyle.Render("Press Enter to play"))
+	b.WriteString(detailSectionStyle.Render("Cast & Crew"))
 	b.WriteString("\n")
-	b.WriteString(detailActionStyle.Render("Press 'p' to add to playlist"))
+	if len(directors) > 0 {
+		b.WriteString(detailTrackStyle.Render(detailLabelStyle.Render("Directed by") + " " + strings.Join(directors, ", ")))
+		b.WriteString("\n")
+	}
+	if len(writers) > 0 {
+		b.WriteString(detailTrackStyle.Render(detailLabelStyle.Render("Written by") + " " + strings.Join(writers, ", ")))
+		b.WriteString("\n")
+	}
+	for _, line := range cast {
+		b.WriteString(detailTrackStyle.Render(line))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// mediaLines describes the default source's file: container, video and the
+// default audio stream.
+func (m detailModel) mediaLines() string {
+	source, ok := m.item.DefaultSource()
+	if !ok {
+		return ""
+	}
+
+	var lines []string
+	if source.Container != "" {
+		line := "Container: " + strings.ToUpper(source.Container)
+		if source.Bitrate > 0 {
+			line += fmt.Sprintf(" · %.1f Mbps", float64(source.Bitrate)/1e6)
+		}
+		lines = append(lines, line)
+	}
+	if video := source.Streams("Video"); len(video) > 0 {
+		lines = append(lines, "Video: "+videoInfo(video[0]))
+	}
+	if audio := source.Streams("Audio"); len(audio) > 0 {
+		stream := audio[0]
+		for _, candidate := range audio {
+			if chosen(source.DefaultAudioStreamIndex, candidate.Index) {
+				stream = candidate
+			}
+		}
+		lines = append(lines, "Audio: "+audioInfo(stream))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
 	b.WriteString("\n")
-	b.WriteString(detailActionStyle.Render("Press 'q' or Esc to go back"))
+	b.WriteString(detailSectionStyle.Render("Media"))
+	b.WriteString("\n")
+	for _, line := range lines {
+		b.WriteString(detailTrackStyle.Render(line))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func videoInfo(stream jellyfin.MediaStream) string {
+	parts := []string{}
+	if label := resolutionLabel(stream.Width, stream.Height); label != "" {
+		parts = append(parts, label)
+	}
+	if stream.Codec != "" {
+		parts = append(parts, strings.ToUpper(stream.Codec))
+	}
+	if stream.VideoRange != "" && stream.VideoRange != "SDR" {
+		parts = append(parts, stream.VideoRange)
+	}
+	if len(parts) == 0 {
+		return stream.Label()
+	}
+	return strings.Join(parts, " · ")
+}
+
+func audioInfo(stream jellyfin.MediaStream) string {
+	parts := []string{}
+	if stream.Codec != "" {
+		parts = append(parts, strings.ToUpper(stream.Codec))
+	}
+	if stream.ChannelLayout != "" {
+		parts = append(parts, stream.ChannelLayout)
+	} else if stream.Channels > 0 {
+		parts = append(parts, fmt.Sprintf("%d ch", stream.Channels))
+	}
+	if stream.Language != "" {
+		parts = append(parts, stream.Language)
+	}
+	if len(parts) == 0 {
+		return stream.Label()
+	}
+	return strings.Join(parts, " · ")
+}
+
+// resolutionLabel names a frame size by its usual marketing name, judged by
+// width so that letterboxed films still count as 1080p or 4K.
+func resolutionLabel(width, height int) string {
+	switch {
+	case width >= 3800:
+		return fmt.Sprintf("4K (%dx%d)", width, height)
+	case width >= 1900:
+		return fmt.Sprintf("1080p (%dx%d)", width, height)
+	case width >= 1260:
+		return fmt.Sprintf("720p (%dx%d)", width, height)
+	case width > 0 && height > 0:
+		return fmt.Sprintf("%dx%d", width, height)
+	default:
+		return ""
+	}
+}
+
+// formatRuntime renders a running time the way listings do, e.g. "2h 12m".
+func formatRuntime(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+// trackLines lists the source's audio and subtitle streams, marking the
+// chosen ones.
+func (m detailModel) trackLines() string {
+	source, ok := m.item.DefaultSource()
+	if !ok {
+		return ""
+	}
+	audio := source.Streams("Audio")
+	subtitles := source.Streams("Subtitle")
+	if len(audio) < 2 && len(subtitles) == 0 {
+		return ""
+	}
 
+	var b strings.Builder
+	b.WriteString("\n")
+	if len(audio) > 0 {
+		b.WriteString(detailSectionStyle.Render("Audio ('t' to change)"))
+		b.WriteString("\n")
+		for _, stream := range audio {
+			b.WriteString(trackOptionLine(stream.Label(), chosen(m.tracks.AudioStreamIndex, stream.Index)))
+		}
+	}
+	if len(subtitles) > 0 {
+		b.WriteString(detailSectionStyle.Render("Subtitles ('c' to change)"))
+		b.WriteString("\n")
+		b.WriteString(trackOptionLine("Off", chosen(m.tracks.SubtitleStreamIndex, jellyfin.NoSubtitles)))
+		for _, stream := range subtitles {
+			label := stream.Label()
+			if stream.IsExternal {
+				label += " (external)"
+			}
+			b.WriteString(trackOptionLine(label, chosen(m.tracks.SubtitleStreamIndex, stream.Index)))
+		}
+	}
 	return b.String()
 }
 
+func trackOptionLine(label string, selected bool) string {
+	if selected {
+		return detailSelectedTrackStyle.Render("● "+label) + "\n"
+	}
+	return detailTrackStyle.Render("○ "+label) + "\n"
+}
+
+func chosen(index *int, value int) bool {
+	return index != nil && *index == value
+}
+
+// defaultTracks picks the tracks to offer first: the user's preferred
+// languages, otherwise the source's defaults.
+func (m detailModel) defaultTracks() jellyfin.TrackSelection {
+	source, ok := m.item.DefaultSource()
+	if !ok {
+		return jellyfin.TrackSelection{}
+	}
+
+	languages := m.config.Languages[m.client.UserID()]
+	tracks := source.PreferredTracks(jellyfin.TrackSelection{MediaSourceID: source.ID}, languages.Audio, languages.Subtitle)
+	if tracks.AudioStreamIndex == nil {
+		tracks.AudioStreamIndex = source.DefaultAudioStreamIndex
+	}
+	if tracks.SubtitleStreamIndex == nil {
+		index := jellyfin.NoSubtitles
+		if source.DefaultSubtitleStreamIndex != nil {
+			index = *source.DefaultSubtitleStreamIndex
+		}
+		tracks.SubtitleStreamIndex = &index
+	}
+	return tracks
+}
+
+func (m detailModel) cycleAudio() (detailModel, tea.Cmd) {
+	source, ok := m.item.DefaultSource()
+	if !ok {
+		return m, nil
+	}
+	audio := source.Streams("Audio")
+	if len(audio) == 0 {
+		return m, nil
+	}
+
+	next := audio[0]
+	for i, stream := range audio {
+		if chosen(m.tracks.AudioStreamIndex, stream.Index) {
+			next = audio[(i+1)%len(audio)]
+		}
+	}
+	m.tracks.AudioStreamIndex = &next.Index
+
+	return m, m.savePreference(func(p *config.LanguagePreference) {
+		p.Audio = next.Language
+	})
+}
+
+// cycleSubtitles steps through "Off" and then each subtitle stream.
+func (m detailModel) cycleSubtitles() (detailModel, tea.Cmd) {
+	source, ok := m.item.DefaultSource()
+	if !ok {
+		return m, nil
+	}
+	subtitles := source.Streams("Subtitle")
+	if len(subtitles) == 0 {
+		return m, nil
+	}
+
+	options := append([]jellyfin.MediaStream{{Index: jellyfin.NoSubtitles, Language: "none"}}, subtitles...)
+	next := options[0]
+	for i, stream := range options {
+		if chosen(m.tracks.SubtitleStreamIndex, stream.Index) {
+			next = options[(i+1)%len(options)]
+		}
+	}
+	m.tracks.SubtitleStreamIndex = &next.Index
+
+	return m, m.savePreference(func(p *config.LanguagePreference) {
+		p.Subtitle = next.Language
+	})
+}
+
+// savePreference remembers a track choice as the user's language
+// preference, so other items start with the same languages. Streams without
+// a language tag leave the preference alone.
+func (m detailModel) savePreference(update func(*config.LanguagePreference)) tea.Cmd {
+	userID := m.client.UserID()
+	if m.config.Languages == nil {
+		m.config.Languages = make(map[string]config.LanguagePreference)
+	}
+
+	preference := m.config.Languages[userID]
+	before := preference
+	update(&preference)
+	if preference == before || (preference.Audio == "" && before.Audio != "") ||
+		(preference.Subtitle == "" && before.Subtitle != "") {
+		return nil
+	}
+	m.config.Languages[userID] = preference
+
+	cfg := m.config.Copy()
+	return func() tea.Msg {
+		if err := config.Save(cfg); err != nil {
+			return errorMsg{err}
+		}
+		return nil
+	}
+}
+
 func (m detailModel) playMedia() tea.Msg {
 	if m.item != nil {
-		streamURL := m.client.GetStreamURL(m.item.ID)
-		err := playWithMPV(streamURL)
-		if err != nil {
-			return errors.NewAPIError(fmt.Sprintf("Failed to play media: %v", err))
-		}
+		return playItemMsg{item: *m.item, startAt: m.item.UserData.PlaybackPosition(), tracks: m.tracks}
+	}
+	return nil
+}
+
+func (m detailModel) playFromStart() tea.Msg {
+	if m.item != nil {
+		return playItemMsg{item: *m.item, tracks: m.tracks}
 	}
 	return nil
 }
 
+func (m detailModel) refresh() tea.Msg {
+	item, err := m.client.GetItemDetails(m.item.ID)
+	if err != nil {
+		return errorMsg{err}
+	}
+	return *item
+}
+
+// loadPoster fetches and renders the item's poster off the UI goroutine.
+// Artwork is decoration, so failures just leave the view without it.
+func (m detailModel) loadPoster() tea.Msg {
+	if m.item == nil || !m.artwork.Enabled() {
+		return nil
+	}
+	poster, err := m.artwork.Poster(*m.item, posterCols, posterRows)
+	if err != nil || poster == "" {
+		return nil
+	}
+	return posterMsg{itemID: m.item.ID, poster: poster}
+}
+
 func (m detailModel) addToPlaylist() tea.Msg {
 	if m.item != nil {
 		return addToPlaylistMsg{itemID: m.item.ID}
@@ -105,14 +591,14 @@ func (m detailModel) addToPlaylist() tea.Msg {
 }
 
 func (m detailModel) back() tea.Msg {
-	return showBrowseMsg{}
+	return backMsg{}
 }
 
-func playWithMPV(streamURL string) error {
-	cmd := exec.Command("mpv", streamURL)
-	return cmd.Run()
+type addToPlaylistMsg struct {
+	itemID string
 }
 
-type addToPlaylistMsg struct {
+type posterMsg struct {
 	itemID string
+	poster string
 }
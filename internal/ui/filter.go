@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	filterTitleStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				Background(lipgloss.Color("#7D56F4")).
+				Padding(0, 1)
+
+	filterItemStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA"))
+
+	filterSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				Background(lipgloss.Color("#FAFAFA"))
+)
+
+// browseFilter narrows the browse view to one item type, by the type name
+// the server's IncludeItemTypes takes.
+type browseFilter struct {
+	label    string
+	itemType string
+}
+
+var browseFilters = []browseFilter{
+	{"Everything", ""},
+	{"Movies", "Movie"},
+	{"Series", "Series"},
+	{"Episodes", "Episode"},
+	{"Albums", "MusicAlbum"},
+	{"Songs", "Audio"},
+}
+
+// filterModel picks the browse view's item type filter.
+type filterModel struct {
+	cursor int
+}
+
+// newFilterModel starts on the filter in effect.
+func newFilterModel(current string) filterModel {
+	m := filterModel{}
+	for i, filter := range browseFilters {
+		if filter.itemType == current {
+			m.cursor = i
+		}
+	}
+	return m
+}
+
+func (m filterModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m filterModel) Update(msg tea.Msg) (filterModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(browseFilters)-1 {
+				m.cursor++
+			}
+		case "enter":
+			itemType := browseFilters[m.cursor].itemType
+			return m, func() tea.Msg { return filterChosenMsg{itemType: itemType} }
+		case "esc":
+			return m, m.back
+		}
+	}
+	return m, nil
+}
+
+func (m filterModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(filterTitleStyle.Render("Filter"))
+	b.WriteString("\n\n")
+
+	for i, filter := range browseFilters {
+		if i == m.cursor {
+			b.WriteString(filterSelectedStyle.Render("> " + filter.label))
+		} else {
+			b.WriteString(filterItemStyle.Render("  " + filter.label))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString("Press Enter to show only items of this type, across all libraries\n")
+	b.WriteString("Press Esc to go back")
+
+	return b.String()
+}
+
+func (m filterModel) back() tea.Msg {
+	return backMsg{}
+}
+
+type filterChosenMsg struct {
+	itemType string
+}
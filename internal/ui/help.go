@@ -35,7 +35,7 @@ func (m helpModel) Update(msg tea.Msg) (helpModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "q", "esc":
+		case "esc":
 			return m, m.back
 		}
 	}
@@ -53,23 +53,78 @@ func (m helpModel) View() string {
 	b.WriteString(helpContentStyle.Render("j/down: Move cursor down\n"))
 	b.WriteString(helpContentStyle.Render("k/up: Move cursor up\n"))
 	b.WriteString(helpContentStyle.Render("enter: Select item\n"))
-	b.WriteString(helpContentStyle.Render("q/esc: Go back/quit\n"))
+	b.WriteString(helpContentStyle.Render("esc: Go back to the previous view\n"))
+	b.WriteString(helpContentStyle.Render("q: Go back, or quit from the home view\n"))
+	b.WriteString(helpContentStyle.Render("ctrl+x: Dismiss the error banner\n"))
+	b.WriteString(helpContentStyle.Render("ctrl+n: Show recent notifications\n"))
+	b.WriteString("\n")
+
+	b.WriteString(helpSectionStyle.Render("Home View"))
+	b.WriteString("\n")
+	b.WriteString(helpContentStyle.Render("up/down: Change row\n"))
+	b.WriteString(helpContentStyle.Render("left/right: Move within a row\n"))
+	b.WriteString(helpContentStyle.Render("enter: Open item\n"))
+	b.WriteString(helpContentStyle.Render("r: Refresh\n"))
+	b.WriteString(helpContentStyle.Render("b: Browse libraries\n"))
 	b.WriteString("\n")
 
 	b.WriteString(helpSectionStyle.Render("Browse View"))
 	b.WriteString("\n")
-	b.WriteString(helpContentStyle.Render("f: Toggle filters\n"))
+	b.WriteString(helpContentStyle.Render("enter: Open library or folder\n"))
+	b.WriteString(helpContentStyle.Render("backspace: Go up one level\n"))
+	b.WriteString(helpContentStyle.Render("f: Filter by item type\n"))
 	b.WriteString(helpContentStyle.Render("s: Search\n"))
 	b.WriteString(helpContentStyle.Render("n: Next page\n"))
 	b.WriteString(helpContentStyle.Render("p: Previous page\n"))
+	b.WriteString(helpContentStyle.Render("u: Switch user\n"))
+	b.WriteString(helpContentStyle.Render("S: Settings\n"))
+	b.WriteString(helpContentStyle.Render("L: Log out\n"))
+	b.WriteString("\n")
+
+	b.WriteString(helpSectionStyle.Render("Series View"))
+	b.WriteString("\n")
+	b.WriteString(helpContentStyle.Render("enter: Open season or episode\n"))
+	b.WriteString(helpContentStyle.Render("n: Play next unwatched episode\n"))
+	b.WriteString(helpContentStyle.Render("backspace: Back to seasons\n"))
+	b.WriteString("\n")
+
+	b.WriteString(helpSectionStyle.Render("Music View"))
+	b.WriteString("\n")
+	b.WriteString(helpContentStyle.Render("enter: Open artist or album, play track\n"))
+	b.WriteString(helpContentStyle.Render("backspace: Go up one level\n"))
 	b.WriteString("\n")
 
 	b.WriteString(helpSectionStyle.Render("Detail View"))
 	b.WriteString("\n")
-	b.WriteString(helpContentStyle.Render("enter: Play media\n"))
+	b.WriteString(helpContentStyle.Render("enter: Play media, resuming where you left off\n"))
+	b.WriteString(helpContentStyle.Render("s: Play from start\n"))
+	b.WriteString(helpContentStyle.Render("t: Change audio track\n"))
+	b.WriteString(helpContentStyle.Render("c: Change subtitles\n"))
 	b.WriteString(helpContentStyle.Render("p: Add to playlist\n"))
 	b.WriteString("\n")
 
+	b.WriteString(helpSectionStyle.Render("Playback"))
+	b.WriteString("\n")
+	b.WriteString(helpContentStyle.Render("space/P: Pause or resume\n"))
+	b.WriteString(helpContentStyle.Render("left/right, </>: Seek 10 seconds\n"))
+	b.WriteString(helpContentStyle.Render("up/down, +/-: Volume\n"))
+	b.WriteString(helpContentStyle.Render("s/X: Stop\n"))
+	b.WriteString(helpContentStyle.Render("n/], p/[: Next or previous queue entry\n"))
+	b.WriteString(helpContentStyle.Render("P, <, >, +, -, X, [ and ] work from any view\n"))
+	b.WriteString("\n")
+
+	b.WriteString(helpSectionStyle.Render("Queue"))
+	b.WriteString("\n")
+	b.WriteString(helpContentStyle.Render("a: Add item, season, album or playlist to the queue\n"))
+	b.WriteString(helpContentStyle.Render("Q: Show the queue\n"))
+	b.WriteString(helpContentStyle.Render("enter: Play entry\n"))
+	b.WriteString(helpContentStyle.Render("J/K: Move entry down or up\n"))
+	b.WriteString(helpContentStyle.Render("d: Remove entry\n"))
+	b.WriteString(helpContentStyle.Render("c: Clear queue\n"))
+	b.WriteString(helpContentStyle.Render("z: Toggle shuffle\n"))
+	b.WriteString(helpContentStyle.Render("r: Cycle repeat off/one/all\n"))
+	b.WriteString("\n")
+
 	b.WriteString(helpSectionStyle.Render("Playlist View"))
 	b.WriteString("\n")
 	b.WriteString(helpContentStyle.Render("enter: Add item to selected playlist\n"))
@@ -79,6 +134,7 @@ func (m helpModel) View() string {
 	b.WriteString(helpSectionStyle.Render("Settings View"))
 	b.WriteString("\n")
 	b.WriteString(helpContentStyle.Render("enter: Edit selected setting\n"))
+	b.WriteString(helpContentStyle.Render("Max bitrate and resolution: leave empty for no limit\n"))
 	b.WriteString("\n")
 
 	b.WriteString(helpContentStyle.Render("Press 'q' or Esc to go back"))
@@ -87,5 +143,5 @@ func (m helpModel) View() string {
 }
 
 func (m helpModel) back() tea.Msg {
-	return showBrowseMsg{}
+	return backMsg{}
 }
@@ -0,0 +1,338 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	homeTitleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#7D56F4")).
+			Padding(0, 1)
+
+	homeRowTitleStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				Bold(true)
+
+	homeCardStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Width(homeCardWidth).
+			PaddingRight(2)
+
+	homeSelectedCardStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				Background(lipgloss.Color("#FAFAFA")).
+				Width(homeCardWidth).
+				PaddingRight(2)
+
+	homeDimStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+)
+
+const (
+	// homeRowLimit is how many items each row fetches.
+	homeRowLimit = 16
+	// homeVisibleCards is how many items of a row fit on screen at once.
+	homeVisibleCards = 4
+	homeCardWidth    = 24
+)
+
+// homeModel is the landing view: rows of items to continue watching, the
+// next episodes of followed series and each library's latest additions.
+type homeModel struct {
+	client  *jellyfin.Client
+	rows    []homeRow
+	row     int
+	loading bool
+	// failed is set when nothing could be loaded.
+	failed bool
+}
+
+type homeRow struct {
+	title  string
+	items  []jellyfin.MediaItem
+	cursor int
+}
+
+func newHomeModel(client *jellyfin.Client) homeModel {
+	return homeModel{
+		client:  client,
+		loading: true,
+	}
+}
+
+func (m homeModel) Init() tea.Cmd {
+	return m.fetchRows
+}
+
+func (m homeModel) Update(msg tea.Msg) (homeModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.row > 0 {
+				m.row--
+			}
+		case "down", "j":
+			if m.row < len(m.rows)-1 {
+				m.row++
+			}
+		case "left":
+			if row := m.current(); row != nil && row.cursor > 0 {
+				row.cursor--
+			}
+		case "right":
+			if row := m.current(); row != nil && row.cursor < len(row.items)-1 {
+				row.cursor++
+			}
+		case "enter":
+			if item, ok := m.selected(); ok {
+				return m, m.open(item)
+			}
+		case "a":
+			if item, ok := m.selected(); ok {
+				return m, enqueueCmd(m.client, item)
+			}
+		case "r":
+			m.loading = true
+			return m, m.fetchRows
+		case "b":
+			return m, m.showBrowse
+		case "s":
+			return m, m.showSearch
+		case "u":
+			return m, m.showUsers
+		case "S":
+			return m, m.showSettings
+		case "L":
+			return m, m.logout
+		}
+	case homeRowsMsg:
+		m.loading = false
+		m.failed = msg.err != nil && len(msg.rows) == 0
+		m.rows, m.row = m.keepPosition(msg.rows)
+		if msg.err != nil {
+			err := msg.err
+			return m, func() tea.Msg { return errorMsg{err} }
+		}
+	case playbackFinishedMsg:
+		// Continue Watching and Next Up have moved on.
+		return m, m.fetchRows
+	}
+	return m, nil
+}
+
+// current returns the focused row, through which cursor moves are stored.
+func (m *homeModel) current() *homeRow {
+	if m.row >= len(m.rows) {
+		return nil
+	}
+	return &m.rows[m.row]
+}
+
+func (m homeModel) selected() (jellyfin.MediaItem, bool) {
+	if m.row >= len(m.rows) || len(m.rows[m.row].items) == 0 {
+		return jellyfin.MediaItem{}, false
+	}
+	row := m.rows[m.row]
+	return row.items[row.cursor], true
+}
+
+// keepPosition carries the focused row and each row's cursor over to freshly
+// fetched rows, matching rows by title.
+func (m homeModel) keepPosition(rows []homeRow) ([]homeRow, int) {
+	focused := ""
+	if m.row < len(m.rows) {
+		focused = m.rows[m.row].title
+	}
+
+	row := 0
+	for i := range rows {
+		for _, old := range m.rows {
+			if old.title == rows[i].title && old.cursor < len(rows[i].items) {
+				rows[i].cursor = old.cursor
+			}
+		}
+		if rows[i].title == focused {
+			row = i
+		}
+	}
+	return rows, row
+}
+
+func (m homeModel) open(item jellyfin.MediaItem) tea.Cmd {
+	if item.Type == "Series" {
+		return func() tea.Msg { return showSeriesMsg{series: item} }
+	}
+	return func() tea.Msg { return showDetailMsg{item: item} }
+}
+
+func (m homeModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(homeTitleStyle.Render("Home"))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading && len(m.rows) == 0:
+		b.WriteString("Loading...\n")
+	case m.failed && len(m.rows) == 0:
+		b.WriteString(homeDimStyle.Render("The home screen could not be loaded. Press 'r' to try again."))
+		b.WriteString("\n")
+	case len(m.rows) == 0:
+		b.WriteString(homeDimStyle.Render("Nothing here yet. Press 'b' to browse your libraries."))
+		b.WriteString("\n")
+	}
+
+	for i, row := range m.rows {
+		title := row.title
+		if len(row.items) > homeVisibleCards {
+			title += fmt.Sprintf(" (%d/%d)", row.cursor+1, len(row.items))
+		}
+		b.WriteString(homeRowTitleStyle.Render(title))
+		b.WriteString("\n")
+		b.WriteString(m.rowView(row, i == m.row))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString("Press arrows to move, Enter to open, 'a' to add to the queue, 'r' to refresh\n")
+	b.WriteString("Press 'b' to browse libraries, 's' to search, 'u' to switch user, 'S' for settings\n")
+	b.WriteString("Press 'L' to log out, 'q' to quit")
+
+	return b.String()
+}
+
+// rowView shows the window of cards around the row's cursor.
+func (m homeModel) rowView(row homeRow, focused bool) string {
+	start := 0
+	if row.cursor >= homeVisibleCards {
+		start = row.cursor - homeVisibleCards + 1
+	}
+	end := start + homeVisibleCards
+	if end > len(row.items) {
+		end = len(row.items)
+	}
+
+	cards := []string{}
+	if start > 0 {
+		cards = append(cards, homeDimStyle.Render("‹ "))
+	}
+	for i := start; i < end; i++ {
+		item := row.items[i]
+		card := fitText(homeCardName(item), homeCardWidth) + "\n" + fitText(homeCardDetail(item), homeCardWidth)
+		if focused && i == row.cursor {
+			cards = append(cards, homeSelectedCardStyle.Render(card))
+		} else {
+			cards = append(cards, homeCardStyle.Render(card))
+		}
+	}
+	if end < len(row.items) {
+		cards = append(cards, homeDimStyle.Render(" ›"))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, cards...)
+}
+
+func homeCardName(item jellyfin.MediaItem) string {
+	if item.Type == "Episode" {
+		return item.SeriesName
+	}
+	return item.Name
+}
+
+// homeCardDetail is the card's second line: the episode, how far along a
+// started item is, or the year.
+func homeCardDetail(item jellyfin.MediaItem) string {
+	var parts []string
+	if item.Type == "Episode" {
+		parts = append(parts, episodeLabel(item)+" "+item.Name)
+	} else if item.ProductionYear > 0 {
+		parts = append(parts, strconv.Itoa(item.ProductionYear))
+	}
+	if position := item.UserData.PlaybackPosition(); position > 0 && item.RunTimeTicks > 0 {
+		parts = append(parts, fmt.Sprintf("%d%%", int(position*100/item.Runtime())))
+	}
+	if item.UserData.UnplayedItemCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d new", item.UserData.UnplayedItemCount))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// fitText cuts s to width runes, marking the cut with an ellipsis.
+func fitText(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// fetchRows loads every row in one go so they appear together and in a
+// stable order. Empty rows are left out, as are rows that failed to load;
+// the first failure is reported alongside the rows that did.
+func (m homeModel) fetchRows() tea.Msg {
+	var rows []homeRow
+	var firstErr error
+	add := func(title string, items []jellyfin.MediaItem, err error) {
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return
+		}
+		if len(items) > 0 {
+			rows = append(rows, homeRow{title: title, items: items})
+		}
+	}
+
+	resume, err := m.client.GetResumeItems(homeRowLimit)
+	add("Continue Watching", resume, err)
+
+	nextUp, err := m.client.GetNextUp(homeRowLimit)
+	add("Next Up", nextUp, err)
+
+	views, err := m.client.GetViews()
+	add("", nil, err)
+	for _, view := range views {
+		switch view.CollectionType {
+		case "playlists", "boxsets", "livetv":
+			// Latest additions make no sense for these.
+			continue
+		}
+		latest, err := m.client.GetLatest(view.ID, homeRowLimit)
+		add("Latest "+view.Name, latest, err)
+	}
+
+	return homeRowsMsg{rows: rows, err: firstErr}
+}
+
+func (m homeModel) showBrowse() tea.Msg {
+	return showBrowseMsg{}
+}
+
+func (m homeModel) showSearch() tea.Msg {
+	return showSearchMsg{}
+}
+
+func (m homeModel) showUsers() tea.Msg {
+	return showUsersMsg{}
+}
+
+func (m homeModel) showSettings() tea.Msg {
+	return showSettingsMsg{}
+}
+
+func (m homeModel) logout() tea.Msg {
+	return logoutMsg{}
+}
+
+type homeRowsMsg struct {
+	rows []homeRow
+	err  error
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
@@ -18,17 +19,37 @@ var (
 
 	focusedButton = focusedStyle.Copy().Render("[ Submit ]")
 	blurredButton = fmt.Sprintf("[ %s ]", blurredStyle.Render("Submit"))
+
+	focusedQuickConnectButton = focusedStyle.Copy().Render("[ Quick Connect ]")
+	blurredQuickConnectButton = fmt.Sprintf("[ %s ]", blurredStyle.Render("Quick Connect"))
+
+	quickConnectCodeStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("205")).
+				Bold(true).
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("205")).
+				Padding(1, 4)
 )
 
+const quickConnectPollInterval = 3 * time.Second
+
 type loginModel struct {
 	focusIndex int
 	inputs     []string
 	cursorMode cursor
+	client     *jellyfin.Client
+	submitting bool
+	err        error
+
+	// quickConnect is set while waiting for a Quick Connect code to be
+	// approved from another device.
+	quickConnect *jellyfin.QuickConnectState
 }
 
-func newLoginModel() loginModel {
+func newLoginModel(client *jellyfin.Client) loginModel {
 	return loginModel{
 		inputs: make([]string, 2),
+		client: client,
 	}
 }
 
@@ -43,30 +64,60 @@ func (m loginModel) Init() tea.Cmd {
 	return tea.EnterAltScreen
 }
 
+func (m loginModel) quickConnectIndex() int {
+	return len(m.inputs) + 1
+}
+
 func (m loginModel) Update(msg tea.Msg) (loginModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.quickConnect != nil {
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "esc":
+				m.quickConnect = nil
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
-		case "ctrl+c", "esc":
+		case "ctrl+c":
 			return m, tea.Quit
+		case "esc":
+			return m, m.cancel
 
 		case "tab", "shift+tab", "enter", "up", "down":
 			s := msg.String()
 
 			if s == "enter" && m.focusIndex == len(m.inputs) {
+				if m.submitting {
+					return m, nil
+				}
+				m.submitting = true
+				m.err = nil
 				return m, m.loginCmd
 			}
 
+			if s == "enter" && m.focusIndex == m.quickConnectIndex() {
+				if m.submitting {
+					return m, nil
+				}
+				m.submitting = true
+				m.err = nil
+				return m, m.startQuickConnect
+			}
+
 			if s == "up" || s == "shift+tab" {
 				m.focusIndex--
 			} else {
 				m.focusIndex++
 			}
 
-			if m.focusIndex > len(m.inputs) {
+			if m.focusIndex > m.quickConnectIndex() {
 				m.focusIndex = 0
 			} else if m.focusIndex < 0 {
-				m.focusIndex = len(m.inputs)
+				m.focusIndex = m.quickConnectIndex()
 			}
 
 			cmds := make([]tea.Cmd, len(m.inputs))
@@ -80,8 +131,15 @@ func (m loginModel) Update(msg tea.Msg) (loginModel, tea.Cmd) {
 
 			return m, tea.Batch(cmds...)
 
+		case "backspace":
+			if m.focusIndex < len(m.inputs) && len(m.inputs[m.focusIndex]) > 0 {
+				input := []rune(m.inputs[m.focusIndex])
+				m.inputs[m.focusIndex] = string(input[:len(input)-1])
+			}
+			return m, nil
+
 		default:
-			if m.focusIndex == len(m.inputs) {
+			if m.focusIndex >= len(m.inputs) {
 				return m, nil
 			}
 
@@ -89,6 +147,44 @@ func (m loginModel) Update(msg tea.Msg) (loginModel, tea.Cmd) {
 			return m, nil
 		}
 
+	case loginErrorMsg:
+		// A status check still in flight when the user cancelled Quick
+		// Connect must not report its error.
+		if msg.secret != "" && (m.quickConnect == nil || m.quickConnect.Secret != msg.secret) {
+			return m, nil
+		}
+		m.submitting = false
+		m.quickConnect = nil
+		m.err = msg.err
+		return m, nil
+
+	case loginSuccessMsg:
+		m.submitting = false
+		m.quickConnect = nil
+		m.err = nil
+		m.inputs[1] = ""
+		return m, nil
+
+	case quickConnectStartedMsg:
+		m.submitting = false
+		m.quickConnect = msg.state
+		return m, pollQuickConnect(msg.state.Secret)
+
+	case quickConnectPollMsg:
+		if m.quickConnect == nil || m.quickConnect.Secret != msg.secret {
+			return m, nil
+		}
+		return m, m.checkQuickConnect(msg.secret)
+
+	case quickConnectStateMsg:
+		if m.quickConnect == nil || m.quickConnect.Secret != msg.secret {
+			return m, nil
+		}
+		if msg.authenticated {
+			return m, m.completeQuickConnect(msg.secret)
+		}
+		return m, pollQuickConnect(msg.secret)
+
 	case cursor:
 		m.cursorMode = msg
 		return m, nil
@@ -99,6 +195,10 @@ func (m loginModel) Update(msg tea.Msg) (loginModel, tea.Cmd) {
 }
 
 func (m loginModel) View() string {
+	if m.quickConnect != nil {
+		return m.quickConnectView()
+	}
+
 	var b strings.Builder
 
 	for i := 0; i < len(m.inputs); i++ {
@@ -112,6 +212,36 @@ func (m loginModel) View() string {
 	}
 	fmt.Fprintf(&b, "\n%s\n", *button)
 
+	quickConnectButton := &blurredQuickConnectButton
+	if m.focusIndex == m.quickConnectIndex() {
+		quickConnectButton = &focusedQuickConnectButton
+	}
+	fmt.Fprintf(&b, "%s\n", *quickConnectButton)
+
+	if m.submitting {
+		b.WriteString("\n" + blurredStyle.Render("Signing in..."))
+	}
+	if m.err != nil {
+		b.WriteString("\n" + errors.FormatError(m.err))
+	}
+
+	return b.String()
+}
+
+func (m loginModel) quickConnectView() string {
+	var b strings.Builder
+
+	b.WriteString(focusedStyle.Render("Quick Connect"))
+	b.WriteString("\n\n")
+	b.WriteString("Approve this code from a signed-in Jellyfin client\n")
+	b.WriteString("(user menu > Quick Connect):\n\n")
+
+	code := strings.Join(strings.Split(m.quickConnect.Code, ""), " ")
+	b.WriteString(quickConnectCodeStyle.Render(code))
+	b.WriteString("\n\n")
+
+	b.WriteString(blurredStyle.Render("Waiting for authorization... Press Esc to cancel"))
+
 	return b.String()
 }
 
@@ -137,16 +267,93 @@ func (m loginModel) inputField(i int) string {
 }
 
 func (m loginModel) loginCmd() tea.Msg {
-	username := m.inputs[0]
+	username := strings.TrimSpace(m.inputs[0])
 	password := m.inputs[1]
 
-	// Here you would typically call your Jellyfin client to perform the login
-	// For this example, we'll just simulate a login
-	if username == "admin" && password == "password" {
-		return loginSuccessMsg{}
+	if username == "" {
+		return loginErrorMsg{err: errors.NewInputError("Username is required")}
+	}
+
+	result, err := m.client.Login(username, password)
+	if err != nil {
+		return loginErrorMsg{err: err}
+	}
+
+	return loginSuccessMsg{user: result.User, token: result.AccessToken}
+}
+
+func (m loginModel) startQuickConnect() tea.Msg {
+	enabled, err := m.client.QuickConnectEnabled()
+	if err != nil {
+		return loginErrorMsg{err: err}
+	}
+	if !enabled {
+		return loginErrorMsg{err: errors.NewAuthenticationError("Quick Connect is disabled on this server")}
+	}
+
+	state, err := m.client.InitiateQuickConnect()
+	if err != nil {
+		return loginErrorMsg{err: err}
+	}
+
+	return quickConnectStartedMsg{state: state}
+}
+
+func pollQuickConnect(secret string) tea.Cmd {
+	return tea.Tick(quickConnectPollInterval, func(time.Time) tea.Msg {
+		return quickConnectPollMsg{secret: secret}
+	})
+}
+
+func (m loginModel) checkQuickConnect(secret string) tea.Cmd {
+	return func() tea.Msg {
+		state, err := m.client.GetQuickConnectState(secret)
+		if err != nil {
+			return loginErrorMsg{err: err, secret: secret}
+		}
+		return quickConnectStateMsg{secret: secret, authenticated: state.Authenticated}
+	}
+}
+
+func (m loginModel) completeQuickConnect(secret string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.client.AuthenticateWithQuickConnect(secret)
+		if err != nil {
+			return loginErrorMsg{err: err, secret: secret}
+		}
+		return loginSuccessMsg{user: result.User, token: result.AccessToken}
 	}
+}
 
-	return errors.AppError{Type: "Login Error", Message: "Invalid username or password"}
+func (m loginModel) cancel() tea.Msg {
+	return cancelLoginMsg{}
 }
 
-type loginSuccessMsg struct{}
+// cancelLoginMsg leaves the login view: back to where the user was when
+// their session expired, or out of the app if there is nothing to go back to.
+type cancelLoginMsg struct{}
+
+type loginSuccessMsg struct {
+	user  jellyfin.User
+	token string
+}
+
+// loginErrorMsg reports a failed login. secret is set for a Quick Connect
+// flow's errors.
+type loginErrorMsg struct {
+	err    error
+	secret string
+}
+
+type quickConnectStartedMsg struct {
+	state *jellyfin.QuickConnectState
+}
+
+type quickConnectPollMsg struct {
+	secret string
+}
+
+type quickConnectStateMsg struct {
+	secret        string
+	authenticated bool
+}
@@ -1,103 +1,517 @@
 package ui
 
 import (
+	"time"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/artwork"
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/config"
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/player"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/queue"
 	"github.com/charmbracelet/bubbletea"
 )
 
+// sessionRetryInterval is how long to wait before checking a stored session
+// again after the server could not be reached.
+const sessionRetryInterval = 10 * time.Second
+
 type Model struct {
 	client        *jellyfin.Client
-	config        config.Config
-	state         string
+	player        player.Player
+	artwork       *artwork.Store
+	config        *config.Config
+	router        router
 	loginModel    loginModel
+	homeModel     homeModel
 	browseModel   browseModel
+	filterModel   filterModel
 	detailModel   detailModel
 	searchModel   searchModel
 	playlistModel playlistModel
+	newPlaylist   createPlaylistModel
 	settingsModel settingsModel
 	helpModel     helpModel
-	error         error
+	usersModel    userPickerModel
+	seriesModel   seriesModel
+	musicModel    musicModel
+	nowPlaying    nowPlayingModel
+	queueModel    queueModel
+	notifications notificationsModel
+	notifier      notifier
+
+	// expiredUserID is the user whose session expired while the login view
+	// is open to renew it.
+	expiredUserID string
+
+	// playback is the item being played, if any.
+	playback *playbackSession
+
+	queue *queue.Queue
 }
 
-func NewModel(client *jellyfin.Client, cfg config.Config) Model {
-	return Model{
+func NewModel(client *jellyfin.Client, cfg config.Config, p player.Player, images *artwork.Store) Model {
+	m := Model{
 		client:        client,
-		config:        cfg,
-		state:         "login",
-		loginModel:    newLoginModel(),
-		browseModel:   newBrowseModel(),
-		detailModel:   newDetailModel(),
-		searchModel:   newSearchModel(),
-		playlistModel: newPlaylistModel(),
-		settingsModel: newSettingsModel(),
+		player:        p,
+		artwork:       images,
+		config:        &cfg,
+		router:        newRouter(loginView),
+		loginModel:    newLoginModel(client),
+		homeModel:     newHomeModel(client),
+		browseModel:   newBrowseModel(client),
+		detailModel:   newDetailModel(client, &cfg, images),
+		searchModel:   newSearchModel(client),
+		playlistModel: newPlaylistModel(client, ""),
+		settingsModel: newSettingsModel(&cfg),
 		helpModel:     newHelpModel(),
+		usersModel:    newUserPickerModel(client),
+		queue:         queue.New(),
 	}
+
+	if cfg.APIKey != "" {
+		client.UseAPIKey(cfg.APIKey, cfg.UserID)
+		m.loginModel.submitting = true
+	} else if session, ok := config.LoadSession(cfg.ServerURL); ok {
+		client.SetSession(session.AccessToken, session.UserID)
+		m.loginModel.submitting = true
+	}
+
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	if m.client.Token() != "" {
+		return tea.Batch(m.restoreSession, m.awaitSessionExpiry)
+	}
+	return m.awaitSessionExpiry
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if m, cmd, handled := m.updatePlayback(msg); handled {
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
+		case "ctrl+c":
+			return m.quit()
+		case "ctrl+x":
+			m.notifier = m.notifier.dismiss()
+			return m, nil
+		case "ctrl+n":
+			m = m.open(notificationsView)
+			m.notifications = newNotificationsModel(m.notifier.history)
+			return m, nil
+		}
+		if m.capturingText() {
+			break
+		}
+		switch msg.String() {
+		case "q":
+			// Like Esc, except that at the root it quits.
+			if len(m.router.stack) > 1 {
+				return m.back(), nil
+			}
+			return m.quit()
 		case "h":
-			m.state = "help"
+			return m.open(helpView), nil
+		}
+		if control, ok := playerKeys[msg.String()]; ok {
+			return m, m.control(control)
+		}
+		if msg.String() == "Q" {
+			return m, func() tea.Msg { return showQueueMsg{} }
+		}
+	case loginSuccessMsg:
+		m.loginModel, _ = m.loginModel.Update(msg)
+		m.saveSession(msg.user, msg.token)
+		if m.expiredUserID != "" {
+			// Pick up where the expired session left off, unless someone
+			// else logged in.
+			sameUser := msg.user.ID == m.expiredUserID
+			m.expiredUserID = ""
+			m.notifier = m.notifier.dismiss()
+			m.client.ResumeSession(sameUser)
+			if sameUser {
+				return m.back(), nil
+			}
+			m = m.resetViews()
+			m.router = newRouter(homeView)
+			return m, tea.Batch(m.homeModel.Init(), m.browseModel.Init(), m.playback.abandon())
+		}
+		m.router = newRouter(homeView)
+		return m, tea.Batch(m.homeModel.Init(), m.browseModel.Init())
+	case switchUserMsg:
+		return m, switchUserCmd(m.client, msg.user, msg.password, m.playback.stopNow())
+	case cancelLoginMsg:
+		if len(m.router.stack) == 1 {
+			return m.quit()
+		}
+		// Giving up on logging in again fails the requests held since the
+		// session expired.
+		m.expiredUserID = ""
+		m.notifier = m.notifier.dismiss()
+		m.client.ResumeSession(false)
+		return m.back(), nil
+	case userSwitchedMsg:
+		m.saveSession(msg.user, msg.token)
+		m = m.resetViews()
+		m.router = newRouter(homeView)
+		return m, tea.Batch(m.homeModel.Init(), m.browseModel.Init())
+	case backMsg:
+		return m.back(), nil
+	case showUsersMsg:
+		m = m.open(usersView)
+		m.usersModel = newUserPickerModel(m.client)
+		return m, m.usersModel.Init()
+	case showBrowseMsg:
+		return m.open(browseView), nil
+	case showFilterMsg:
+		m = m.open(filterView)
+		m.filterModel = newFilterModel(m.browseModel.filter)
+		return m, nil
+	case filterChosenMsg:
+		m = m.back()
+		m.browseModel, cmd = m.browseModel.setFilter(msg.itemType)
+		return m, cmd
+	case showSearchMsg:
+		return m.open(searchView), nil
+	case showSettingsMsg:
+		m = m.open(settingsView)
+		m.settingsModel, cmd = m.settingsModel.Update(msg)
+		return m, cmd
+	case addToPlaylistMsg:
+		m = m.open(playlistView)
+		m.playlistModel = newPlaylistModel(m.client, msg.itemID)
+		return m, m.playlistModel.Init()
+	case playlistUpdateMsg:
+		return m.back().notify(severitySuccess, msg.message)
+	case showCreatePlaylistMsg:
+		m = m.open(createPlaylistView)
+		m.newPlaylist = newCreatePlaylistModel(m.client)
+		return m, nil
+	case playlistCreatedMsg:
+		var notifyCmd tea.Cmd
+		m = m.back()
+		m.playlistModel, cmd = m.playlistModel.Update(msg)
+		m, notifyCmd = m.notify(severitySuccess, "Playlist created")
+		return m, tea.Batch(cmd, notifyCmd)
+	case showSeriesMsg:
+		m = m.open(seriesView)
+		m.seriesModel = newSeriesModel(m.client, msg.series)
+		return m, m.seriesModel.Init()
+	case showMusicMsg:
+		m = m.open(musicView)
+		m.musicModel = newMusicModel(m.client, msg.library)
+		return m, m.musicModel.Init()
+	case playbackFinishedMsg:
+		// The detail and home views refresh resume positions even while
+		// hidden.
+		var homeCmd tea.Cmd
+		m.detailModel, cmd = m.detailModel.Update(msg)
+		m.homeModel, homeCmd = m.homeModel.Update(msg)
+		return m, tea.Batch(cmd, homeCmd)
+	case homeRowsMsg:
+		m.homeModel, cmd = m.homeModel.Update(msg)
+		return m, cmd
+	case jellyfin.MediaItem, posterMsg:
+		m.detailModel, cmd = m.detailModel.Update(msg)
+		return m, cmd
+	case showDetailMsg:
+		m = m.open(detailView)
+		m.detailModel = m.detailModel.show(msg.item)
+		return m, m.detailModel.refresh
+	case sessionExpiredMsg:
+		m.expiredUserID = m.client.UserID()
+		m.loginModel = newLoginModel(m.client)
+		if session, ok := config.LoadSession(m.config.ServerURL); ok {
+			m.loginModel.inputs[0] = session.UserName
+			m.loginModel.focusIndex = 1
+		}
+		config.DeleteSession(m.config.ServerURL)
+		m = m.open(loginView)
+		m, cmd = m.notify(severityWarning, "Your session has expired. Log in again to continue where you left off.")
+		return m, tea.Batch(cmd, m.awaitSessionExpiry)
+	case sessionUnavailableMsg:
+		m.loginModel, _ = m.loginModel.Update(loginErrorMsg{err: msg.err})
+		return m, tea.Tick(sessionRetryInterval, func(time.Time) tea.Msg {
+			return retrySessionMsg{}
+		})
+	case retrySessionMsg:
+		// Unless the user logged in by hand in the meantime.
+		if m.router.current() != loginView || m.loginModel.submitting || m.client.Token() == "" {
+			return m, nil
+		}
+		m.loginModel.submitting = true
+		m.loginModel.err = nil
+		return m, m.restoreSession
+	case sessionInvalidMsg:
+		if !m.client.UsingAPIKey() {
+			config.DeleteSession(m.config.ServerURL)
+		}
+		m.client.ClearCredentials()
+		m.loginModel, _ = m.loginModel.Update(loginErrorMsg{err: msg.err})
+		return m, nil
+	case logoutMsg:
+		return m, m.logout(m.playback.stopNow())
+	case loggedOutMsg:
+		m.router = newRouter(loginView)
+		m = m.resetViews()
+		m.loginModel = newLoginModel(m.client)
+		if msg.err != nil {
+			m.loginModel.err = msg.err
+		}
+		return m, nil
+	case errorMsg:
+		if errors.Is(msg.err, jellyfin.ErrSessionEnded) {
+			// Left over from a session that was replaced; not worth a banner.
 			return m, nil
 		}
+		return m.notify(severityError, msg.err.Error())
 	case errors.AppError:
-		m.error = msg
+		return m.notify(severityError, msg.Error())
+	case notifyMsg:
+		return m.notify(msg.severity, msg.message)
+	case toastExpiredMsg:
+		m.notifier = m.notifier.expire(msg.id)
+		return m, nil
+	case clearNotificationsMsg:
+		m.notifier = notifier{nextID: m.notifier.nextID}
+		m.notifications = newNotificationsModel(nil)
 		return m, nil
 	}
 
-	switch m.state {
-	case "login":
+	switch m.router.current() {
+	case loginView:
 		m.loginModel, cmd = m.loginModel.Update(msg)
-	case "browse":
+	case homeView:
+		m.homeModel, cmd = m.homeModel.Update(msg)
+	case browseView:
 		m.browseModel, cmd = m.browseModel.Update(msg)
-	case "detail":
+	case filterView:
+		m.filterModel, cmd = m.filterModel.Update(msg)
+	case detailView:
 		m.detailModel, cmd = m.detailModel.Update(msg)
-	case "search":
+	case searchView:
 		m.searchModel, cmd = m.searchModel.Update(msg)
-	case "playlist":
+	case playlistView:
 		m.playlistModel, cmd = m.playlistModel.Update(msg)
-	case "settings":
+	case createPlaylistView:
+		m.newPlaylist, cmd = m.newPlaylist.Update(msg)
+	case settingsView:
 		m.settingsModel, cmd = m.settingsModel.Update(msg)
-	case "help":
+	case helpView:
 		m.helpModel, cmd = m.helpModel.Update(msg)
+	case usersView:
+		m.usersModel, cmd = m.usersModel.Update(msg)
+	case seriesView:
+		m.seriesModel, cmd = m.seriesModel.Update(msg)
+	case musicView:
+		m.musicModel, cmd = m.musicModel.Update(msg)
+	case nowPlayingView:
+		m.nowPlaying, cmd = m.nowPlaying.Update(msg)
+	case queueView:
+		m.queueModel, cmd = m.queueModel.Update(msg)
+	case notificationsView:
+		m.notifications, cmd = m.notifications.Update(msg)
 	}
 
 	return m, cmd
 }
 
 func (m Model) View() string {
-	if m.error != nil {
-		return errors.ErrorStyle.Render(m.error.Error())
+	view := m.notifier.frame(m.view())
+	if m.router.current() == detailView {
+		return view
 	}
+	// Only the detail view draws artwork; take down any it left behind.
+	return m.artwork.Clear() + view
+}
 
-	switch m.state {
-	case "login":
+func (m Model) view() string {
+	switch m.router.current() {
+	case loginView:
 		return m.loginModel.View()
-	case "browse":
+	case homeView:
+		return m.homeModel.View()
+	case browseView:
 		return m.browseModel.View()
-	case "detail":
+	case filterView:
+		return m.filterModel.View()
+	case detailView:
 		return m.detailModel.View()
-	case "search":
+	case searchView:
 		return m.searchModel.View()
-	case "playlist":
+	case playlistView:
 		return m.playlistModel.View()
-	case "settings":
+	case createPlaylistView:
+		return m.newPlaylist.View()
+	case settingsView:
 		return m.settingsModel.View()
-	case "help":
+	case helpView:
 		return m.helpModel.View()
+	case usersView:
+		return m.usersModel.View()
+	case seriesView:
+		return m.seriesModel.View()
+	case musicView:
+		return m.musicModel.View()
+	case nowPlayingView:
+		return m.nowPlaying.View()
+	case queueView:
+		return m.queueModel.View()
+	case notificationsView:
+		return m.notifications.View()
 	default:
-		return "Unknown state"
+		return "Unknown view"
+	}
+}
+
+// capturingText reports whether the active view is reading free text, in
+// which case global shortcuts must not swallow the keystrokes.
+func (m Model) capturingText() bool {
+	switch m.router.current() {
+	case loginView, searchView, createPlaylistView:
+		return true
+	case usersView:
+		return m.usersModel.prompting
+	case settingsView:
+		return m.settingsModel.editing != nil
+	default:
+		return false
+	}
+}
+
+// notify shows a notification, keeping the history view current.
+func (m Model) notify(sev severity, message string) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.notifier, cmd = m.notifier.notify(sev, message)
+	if m.router.current() == notificationsView {
+		m.notifications.history = m.notifier.history
 	}
+	return m, cmd
+}
+
+// resetViews discards all per-user view state, e.g. after switching users,
+// so nothing from the previous user's libraries is shown.
+func (m Model) resetViews() Model {
+	m.homeModel = newHomeModel(m.client)
+	m.browseModel = newBrowseModel(m.client)
+	m.detailModel = newDetailModel(m.client, m.config, m.artwork)
+	m.searchModel = newSearchModel(m.client)
+	m.playlistModel = newPlaylistModel(m.client, "")
+	m.usersModel = newUserPickerModel(m.client)
+	m.queue.Clear()
+	return m
+}
+
+func (m Model) saveSession(user jellyfin.User, token string) {
+	if m.client.UsingAPIKey() {
+		return
+	}
+	// A failed save only means logging in again on the next launch.
+	config.SaveSession(config.Session{
+		ServerURL:   m.config.ServerURL,
+		UserID:      user.ID,
+		UserName:    user.Name,
+		AccessToken: token,
+	})
+}
+
+func (m Model) restoreSession() tea.Msg {
+	if m.client.UsingAPIKey() {
+		return m.validateAPIKey()
+	}
+
+	user, err := m.client.GetCurrentUser()
+	if err != nil {
+		return sessionCheckFailed(err)
+	}
+	return loginSuccessMsg{user: *user, token: m.client.Token()}
+}
+
+// awaitSessionExpiry waits for the server to reject the access token.
+func (m Model) awaitSessionExpiry() tea.Msg {
+	<-m.client.SessionExpired()
+	return sessionExpiredMsg{}
+}
+
+func (m Model) validateAPIKey() tea.Msg {
+	if m.client.UserID() == "" {
+		return sessionInvalidMsg{errors.NewInputError("api_key requires user_id to be set in config.json")}
+	}
+
+	user, err := m.client.GetUser(m.client.UserID())
+	if err != nil {
+		return sessionCheckFailed(err)
+	}
+	return loginSuccessMsg{user: *user, token: m.client.Token()}
+}
+
+// sessionCheckFailed ends the stored session only if the server rejected
+// it. Network trouble or a server error says nothing about the token, so the
+// check is tried again later.
+func sessionCheckFailed(err error) tea.Msg {
+	if errors.Is(err, errors.KindAuthentication) || errors.Is(err, errors.KindNotFound) {
+		return sessionInvalidMsg{err}
+	}
+	return sessionUnavailableMsg{err}
+}
+
+// quit stops the player before exiting, so playback does not outlive the
+// TUI.
+func (m Model) quit() (Model, tea.Cmd) {
+	if m.playback != nil {
+		m.player.Stop()
+	}
+	return m, tea.Quit
+}
+
+// logout ends the session, calling stopPlayback, if not nil, first.
+func (m Model) logout(stopPlayback func()) tea.Cmd {
+	return func() tea.Msg {
+		if stopPlayback != nil {
+			stopPlayback()
+		}
+		err := m.client.Logout()
+		config.DeleteSession(m.config.ServerURL)
+		return loggedOutMsg{err}
+	}
+}
+
+type sessionExpiredMsg struct{}
+
+// sessionUnavailableMsg means the stored session could not be checked, e.g.
+// because the server is down.
+type sessionUnavailableMsg struct {
+	err error
+}
+
+type retrySessionMsg struct{}
+
+type sessionInvalidMsg struct {
+	err error
+}
+
+type logoutMsg struct{}
+
+type loggedOutMsg struct {
+	err error
+}
+
+type errorMsg struct {
+	err error
+}
+
+type showBrowseMsg struct{}
+
+// backMsg closes the active view, returning to the one it was opened from.
+type backMsg struct{}
+
+type showDetailMsg struct {
+	item jellyfin.MediaItem
 }
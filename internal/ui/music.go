@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	musicTitleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#7D56F4")).
+			Padding(0, 1)
+
+	musicItemStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA"))
+
+	musicSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				Background(lipgloss.Color("#FAFAFA"))
+
+	musicDimStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+)
+
+type musicLevel int
+
+const (
+	musicArtists musicLevel = iota
+	musicAlbums
+	musicTracks
+)
+
+// musicModel browses a music library as album artists, then their albums,
+// then an album's tracks.
+type musicModel struct {
+	client  *jellyfin.Client
+	library jellyfin.MediaItem
+	level   musicLevel
+	artists []jellyfin.MediaItem
+	albums  []jellyfin.MediaItem
+	tracks  []jellyfin.MediaItem
+	artist  jellyfin.MediaItem
+	album   jellyfin.MediaItem
+
+	// cursors holds the cursor for each level, so going back up lands on
+	// the artist or album that was opened.
+	cursors [3]int
+}
+
+func newMusicModel(client *jellyfin.Client, library jellyfin.MediaItem) musicModel {
+	return musicModel{
+		client:  client,
+		library: library,
+	}
+}
+
+func (m musicModel) Init() tea.Cmd {
+	return m.fetchArtists
+}
+
+func (m musicModel) Update(msg tea.Msg) (musicModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursors[m.level] > 0 {
+				m.cursors[m.level]--
+			}
+		case "down", "j":
+			if m.cursors[m.level] < len(m.list())-1 {
+				m.cursors[m.level]++
+			}
+		case "enter":
+			list := m.list()
+			if len(list) == 0 {
+				return m, nil
+			}
+			selected := list[m.cursors[m.level]]
+
+			switch m.level {
+			case musicArtists:
+				m.artist = selected
+				m.albums = nil
+				m.level = musicAlbums
+				m.cursors[musicAlbums] = 0
+				return m, m.fetchAlbums(selected.ID)
+			case musicAlbums:
+				m.album = selected
+				m.tracks = nil
+				m.level = musicTracks
+				m.cursors[musicTracks] = 0
+				return m, m.fetchTracks(selected.ID)
+			case musicTracks:
+				// Play the rest of the album after the chosen track.
+				tracks, index := m.tracks, m.cursors[musicTracks]
+				return m, func() tea.Msg { return playQueueMsg{items: tracks, index: index} }
+			}
+		case "a":
+			if m.level == musicArtists {
+				return m, nil
+			}
+			list := m.list()
+			if len(list) > 0 {
+				return m, enqueueCmd(m.client, list[m.cursors[m.level]])
+			}
+		case "backspace", "esc":
+			if m.level > musicArtists {
+				m.level--
+				return m, nil
+			}
+			return m, m.back
+		}
+	case artistsMsg:
+		m.artists = msg.artists
+	case albumsMsg:
+		if msg.artistID == m.artist.ID {
+			m.albums = msg.albums
+		}
+	case tracksMsg:
+		if msg.albumID == m.album.ID {
+			m.tracks = msg.tracks
+		}
+	}
+	return m, nil
+}
+
+func (m musicModel) list() []jellyfin.MediaItem {
+	switch m.level {
+	case musicAlbums:
+		return m.albums
+	case musicTracks:
+		return m.tracks
+	default:
+		return m.artists
+	}
+}
+
+func (m musicModel) View() string {
+	var b strings.Builder
+
+	crumbs := []string{m.library.Name}
+	if m.level >= musicAlbums {
+		crumbs = append(crumbs, m.artist.Name)
+	}
+	if m.level >= musicTracks {
+		crumbs = append(crumbs, m.album.Name)
+	}
+	b.WriteString(musicTitleStyle.Render(strings.Join(crumbs, " > ")))
+	b.WriteString("\n\n")
+
+	cursor := m.cursors[m.level]
+	for i, item := range m.list() {
+		line := item.Name
+		if m.level == musicTracks {
+			line = trackLine(item)
+		}
+
+		if i == cursor {
+			b.WriteString(musicSelectedStyle.Render("> " + line))
+		} else {
+			b.WriteString(musicItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch m.level {
+	case musicArtists:
+		b.WriteString("Press Enter to show the artist's albums\n")
+	case musicAlbums:
+		b.WriteString("Press Enter to show the album's tracks, Backspace for artists\n")
+		b.WriteString("Press 'a' to add the album to the queue\n")
+	case musicTracks:
+		b.WriteString("Press Enter to play from the track, Backspace for albums\n")
+		b.WriteString("Press 'a' to add the track to the queue\n")
+	}
+	b.WriteString("Press 'q' or Esc to go back")
+
+	return b.String()
+}
+
+// trackLine formats a track as "1-03  Title  3:45", omitting the disc number
+// when the server has none.
+func trackLine(track jellyfin.MediaItem) string {
+	number := fmt.Sprintf("%02d", track.IndexNumber)
+	if track.ParentIndexNumber > 0 {
+		number = fmt.Sprintf("%d-%s", track.ParentIndexNumber, number)
+	}
+
+	line := fmt.Sprintf("%s  %s", number, track.Name)
+	if track.RunTimeTicks > 0 {
+		line += musicDimStyle.Render("  " + formatDuration(track.Runtime()))
+	}
+	return line
+}
+
+func (m musicModel) fetchArtists() tea.Msg {
+	artists, err := m.client.GetAlbumArtists(m.library.ID)
+	if err != nil {
+		return errorMsg{err}
+	}
+	return artistsMsg{artists: artists}
+}
+
+func (m musicModel) fetchAlbums(artistID string) tea.Cmd {
+	return func() tea.Msg {
+		albums, err := m.client.GetAlbums(artistID)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return albumsMsg{artistID: artistID, albums: albums}
+	}
+}
+
+func (m musicModel) fetchTracks(albumID string) tea.Cmd {
+	return func() tea.Msg {
+		tracks, err := m.client.GetTracks(albumID)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return tracksMsg{albumID: albumID, tracks: tracks}
+	}
+}
+
+func (m musicModel) back() tea.Msg {
+	return backMsg{}
+}
+
+type artistsMsg struct {
+	artists []jellyfin.MediaItem
+}
+
+type albumsMsg struct {
+	artistID string
+	albums   []jellyfin.MediaItem
+}
+
+type tracksMsg struct {
+	albumID string
+	tracks  []jellyfin.MediaItem
+}
+
+type showMusicMsg struct {
+	library jellyfin.MediaItem
+}
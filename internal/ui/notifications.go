@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	bannerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#C0392B")).
+			Padding(0, 1)
+
+	warningBannerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#1A1A1A")).
+				Background(lipgloss.Color("#E5C07B")).
+				Padding(0, 1)
+
+	toastStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#1A1A1A")).
+			Background(lipgloss.Color("#98C379")).
+			Padding(0, 1)
+
+	infoToastStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#7D56F4")).
+			Padding(0, 1)
+
+	notificationsTitleStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				Background(lipgloss.Color("#7D56F4")).
+				Padding(0, 1)
+
+	notificationsItemStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA"))
+
+	notificationsSelectedStyle = lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#7D56F4")).
+					Background(lipgloss.Color("#FAFAFA"))
+
+	notificationsDimStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("240"))
+)
+
+const (
+	// toastDuration is how long success and info toasts stay up. Warnings
+	// and errors stay until dismissed.
+	toastDuration = 3 * time.Second
+	// maxNotifications bounds the history.
+	maxNotifications = 50
+)
+
+type severity int
+
+const (
+	severityInfo severity = iota
+	severitySuccess
+	severityWarning
+	severityError
+)
+
+func (s severity) String() string {
+	switch s {
+	case severitySuccess:
+		return "OK"
+	case severityWarning:
+		return "Warning"
+	case severityError:
+		return "Error"
+	default:
+		return "Info"
+	}
+}
+
+// sticky reports whether notifications of this severity wait to be
+// dismissed rather than expiring.
+func (s severity) sticky() bool {
+	return s >= severityWarning
+}
+
+type notification struct {
+	id       int
+	severity severity
+	message  string
+	at       time.Time
+}
+
+// notifier keeps the banner for the latest unacknowledged problem, the
+// current toast, and a history of recent notifications, newest last.
+type notifier struct {
+	history []notification
+	banner  *notification
+	toast   *notification
+	nextID  int
+}
+
+// notify records a notification and shows it as a banner or a toast. The
+// returned command expires toasts.
+func (n notifier) notify(sev severity, message string) (notifier, tea.Cmd) {
+	n.nextID++
+	note := notification{
+		id:       n.nextID,
+		severity: sev,
+		message:  message,
+		at:       time.Now(),
+	}
+
+	history := n.history
+	if len(history) >= maxNotifications {
+		history = history[len(history)-maxNotifications+1:]
+	}
+	n.history = append(history[:len(history):len(history)], note)
+
+	if sev.sticky() {
+		n.banner = &note
+		return n, nil
+	}
+	n.toast = &note
+	return n, tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: note.id}
+	})
+}
+
+func (n notifier) dismiss() notifier {
+	n.banner = nil
+	return n
+}
+
+func (n notifier) expire(id int) notifier {
+	if n.toast != nil && n.toast.id == id {
+		n.toast = nil
+	}
+	return n
+}
+
+// frame puts the banner above the view and the toast below it.
+func (n notifier) frame(view string) string {
+	if n.banner != nil {
+		style := bannerStyle
+		if n.banner.severity == severityWarning {
+			style = warningBannerStyle
+		}
+		view = style.Render(n.banner.message+"  (ctrl+x to dismiss, ctrl+n for history)") + "\n\n" + view
+	}
+	if n.toast != nil {
+		style := toastStyle
+		if n.toast.severity == severityInfo {
+			style = infoToastStyle
+		}
+		view += "\n\n" + style.Render(n.toast.message)
+	}
+	return view
+}
+
+// notificationsModel lists the notification history, newest first.
+type notificationsModel struct {
+	history []notification
+	cursor  int
+}
+
+func newNotificationsModel(history []notification) notificationsModel {
+	return notificationsModel{history: history}
+}
+
+func (m notificationsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m notificationsModel) Update(msg tea.Msg) (notificationsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.history)-1 {
+				m.cursor++
+			}
+		case "c":
+			return m, func() tea.Msg { return clearNotificationsMsg{} }
+		case "esc":
+			return m, m.back
+		}
+	}
+	return m, nil
+}
+
+func (m notificationsModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(notificationsTitleStyle.Render("Notifications"))
+	b.WriteString("\n\n")
+
+	if len(m.history) == 0 {
+		b.WriteString(notificationsDimStyle.Render("Nothing to report."))
+		b.WriteString("\n")
+	}
+
+	for i := range m.history {
+		note := m.history[len(m.history)-1-i]
+		line := fmt.Sprintf("%s  %-7s %s", note.at.Format("15:04:05"), note.severity, note.message)
+		if i == m.cursor {
+			b.WriteString(notificationsSelectedStyle.Render(line))
+		} else {
+			b.WriteString(notificationsItemStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString("Press 'c' to clear, Esc to go back")
+
+	return b.String()
+}
+
+func (m notificationsModel) back() tea.Msg {
+	return backMsg{}
+}
+
+// notifyMsg asks for a notification, e.g. a toast confirming an action.
+type notifyMsg struct {
+	severity severity
+	message  string
+}
+
+type toastExpiredMsg struct {
+	id int
+}
+
+type clearNotificationsMsg struct{}
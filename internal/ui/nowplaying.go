@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/player"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/queue"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	nowPlayingHeaderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				Background(lipgloss.Color("#7D56F4")).
+				Padding(0, 1)
+
+	nowPlayingTrackStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				Bold(true).
+				MarginLeft(2)
+
+	nowPlayingInfoStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				MarginLeft(2)
+
+	nowPlayingProgressStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("205")).
+				MarginLeft(2)
+)
+
+const progressBarWidth = 40
+
+// nowPlayingModel shows the item being played, kept up to date from the
+// player's events.
+type nowPlayingModel struct {
+	item         jellyfin.MediaItem
+	controllable bool
+	position     time.Duration
+	duration     time.Duration
+	paused       bool
+	volume       float64
+	finished     bool
+	queue        *queue.Queue
+}
+
+func newNowPlayingModel(item jellyfin.MediaItem, position time.Duration, controllable bool, q *queue.Queue) nowPlayingModel {
+	return nowPlayingModel{
+		queue:        q,
+		item:         item,
+		controllable: controllable,
+		position:     position,
+		duration:     item.Runtime(),
+		volume:       -1,
+	}
+}
+
+func (m nowPlayingModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m nowPlayingModel) Update(msg tea.Msg) (nowPlayingModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case " ":
+			return m, m.control(controlTogglePause)
+		case "left":
+			return m, m.control(controlSeekBackward)
+		case "right":
+			return m, m.control(controlSeekForward)
+		case "down":
+			return m, m.control(controlVolumeDown)
+		case "up":
+			return m, m.control(controlVolumeUp)
+		case "s":
+			return m, m.control(controlStop)
+		case "n":
+			return m, m.control(controlNext)
+		case "p":
+			return m, m.control(controlPrevious)
+		case "esc", "backspace":
+			return m, m.back
+		}
+	case playerEventMsg:
+		switch event := msg.event.(type) {
+		case player.PositionEvent:
+			m.position = event.Position
+			if event.Duration > 0 {
+				m.duration = event.Duration
+			}
+		case player.PauseEvent:
+			m.paused = event.Paused
+		case player.VolumeEvent:
+			m.volume = event.Volume
+		case player.EndEvent:
+			m.finished = true
+		}
+	}
+	return m, nil
+}
+
+func (m nowPlayingModel) View() string {
+	var b strings.Builder
+
+	header := "▶ Now Playing"
+	if m.item.MediaType == "Audio" {
+		header = "♪ Now Playing"
+	}
+	switch {
+	case m.finished:
+		header = "■ Stopped"
+	case m.paused:
+		header = "❚❚ Paused"
+	}
+	b.WriteString(nowPlayingHeaderStyle.Render(header))
+	b.WriteString("\n\n")
+
+	b.WriteString(nowPlayingTrackStyle.Render(m.item.Name))
+	b.WriteString("\n")
+	for _, line := range m.infoLines() {
+		b.WriteString(nowPlayingInfoStyle.Render(line))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	progress := fmt.Sprintf("%s %s / %s",
+		progressBar(m.position, m.duration, progressBarWidth),
+		formatDuration(m.position),
+		formatDuration(m.duration),
+	)
+	if m.volume >= 0 {
+		progress += fmt.Sprintf("  Vol %.0f%%", m.volume)
+	}
+	b.WriteString(nowPlayingProgressStyle.Render(progress))
+	b.WriteString("\n")
+	if line := m.queueLine(); line != "" {
+		b.WriteString(nowPlayingInfoStyle.Render(line))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.finished:
+	case m.controllable:
+		b.WriteString("Space to pause, ←/→ to seek, ↑/↓ for volume, 's' to stop\n")
+	default:
+		b.WriteString("Press 's' to stop; use the player's own controls for the rest\n")
+	}
+	b.WriteString("Press 'n'/'p' for next/previous, 'Q' for the queue\n")
+	b.WriteString("Press Esc to go back; playback continues in the background")
+
+	return b.String()
+}
+
+// infoLines describes where the item comes from: artist and album for music,
+// series and episode for TV.
+func (m nowPlayingModel) infoLines() []string {
+	var lines []string
+
+	switch {
+	case m.item.MediaType == "Audio":
+		artist := m.item.AlbumArtist
+		if len(m.item.Artists) > 0 {
+			artist = strings.Join(m.item.Artists, ", ")
+		}
+		if artist != "" {
+			lines = append(lines, artist)
+		}
+
+		album := m.item.Album
+		if m.item.ParentIndexNumber > 0 {
+			album += fmt.Sprintf(" · Disc %d", m.item.ParentIndexNumber)
+		}
+		if m.item.IndexNumber > 0 {
+			album += fmt.Sprintf(" · Track %d", m.item.IndexNumber)
+		}
+		lines = append(lines, album)
+	case m.item.Type == "Episode":
+		lines = append(lines, fmt.Sprintf("%s · %s", m.item.SeriesName, episodeLabel(m.item)))
+	}
+
+	return lines
+}
+
+// queueLine shows where the item sits in the queue and what plays next.
+func (m nowPlayingModel) queueLine() string {
+	if m.queue == nil || m.queue.Len() < 2 {
+		return ""
+	}
+
+	line := fmt.Sprintf("%d of %d in queue", m.queue.Index()+1, m.queue.Len())
+	if next, ok := m.queue.Upcoming(); ok {
+		line += " · Next: " + queueEntryName(next)
+	}
+	if repeat := m.queue.Repeat(); repeat != queue.RepeatOff {
+		line += " · Repeat " + repeat.String()
+	}
+	if m.queue.Shuffle() {
+		line += " · Shuffle"
+	}
+	return line
+}
+
+func progressBar(position, total time.Duration, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(int64(width) * int64(position) / int64(total))
+	}
+	if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// formatDuration renders d as m:ss, or h:mm:ss when it is an hour or longer.
+func formatDuration(d time.Duration) string {
+	total := int(d / time.Second)
+	hours, minutes, seconds := total/3600, total/60%60, total%60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
+
+func (m nowPlayingModel) control(control playerControl) tea.Cmd {
+	return func() tea.Msg {
+		return playerControlMsg{control: control}
+	}
+}
+
+func (m nowPlayingModel) back() tea.Msg {
+	return backMsg{}
+}
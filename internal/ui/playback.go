@@ -0,0 +1,503 @@
+package ui
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/player"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	progressReportInterval = 10 * time.Second
+	seekStep               = 10 * time.Second
+	volumeStep             = 5
+
+	// restartThreshold is how far into an entry "previous" restarts it
+	// rather than going back to the one before.
+	restartThreshold = 3 * time.Second
+
+	// watchedThreshold is the fraction of an item's runtime after which it
+	// is marked as played, matching the server's default.
+	watchedThreshold = 0.9
+)
+
+type playerControl int
+
+const (
+	controlTogglePause playerControl = iota
+	controlSeekBackward
+	controlSeekForward
+	controlVolumeDown
+	controlVolumeUp
+	controlStop
+	controlNext
+	controlPrevious
+)
+
+// playbackSession is an item playing in the configured player. Update owns
+// the position fields and paused; commands only ever receive copies of them.
+type playbackSession struct {
+	item     jellyfin.MediaItem
+	player   player.Player
+	events   <-chan interface{}
+	reporter *playbackReporter
+	position time.Duration
+	// positionKnown is set once position comes from the player or the end
+	// of the item. Players run from a command never report one, and their
+	// start offset must not overwrite the server's resume point.
+	positionKnown bool
+	paused        bool
+}
+
+// startPlaybackCmd plays item in p, starting at startAt, which is zero to
+// play from the beginning or the stored position to resume. The stream is
+// negotiated with the server first, within the limits in opts.
+func startPlaybackCmd(p player.Player, client *jellyfin.Client, item jellyfin.MediaItem, startAt time.Duration, opts jellyfin.StreamOptions) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := client.ResolveStream(item, opts)
+		if err != nil {
+			return errorMsg{err}
+		}
+
+		events, err := p.Play(stream.URL, player.Options{
+			Title:         item.Name,
+			Start:         startAt,
+			AudioOnly:     item.MediaType == "Audio",
+			AudioTrack:    stream.AudioTrack,
+			SubtitleTrack: stream.SubtitleTrack,
+			SubtitleFile:  stream.SubtitleURL,
+		})
+		if err != nil {
+			return errorMsg{errors.Wrap(errors.KindAPI, "Failed to play media", err)}
+		}
+
+		session := &playbackSession{
+			item:     item,
+			player:   p,
+			events:   events,
+			reporter: newPlaybackReporter(client, item, *stream),
+			position: startAt,
+		}
+		session.reporter.start(startAt)
+
+		return playbackStartedMsg{session: session}
+	}
+}
+
+// waitForEvent delivers the next player event to Update. It is re-issued
+// after every event until the player exits.
+func (s *playbackSession) waitForEvent() tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-s.events
+		if !ok {
+			return nil
+		}
+		return playerEventMsg{session: s, event: event}
+	}
+}
+
+func (s *playbackSession) scheduleProgressReport() tea.Cmd {
+	return tea.Tick(progressReportInterval, func(time.Time) tea.Msg {
+		return playbackProgressTickMsg{session: s}
+	})
+}
+
+func (s *playbackSession) reportProgress() tea.Cmd {
+	position, known, paused := s.position, s.positionKnown, s.paused
+	return func() tea.Msg {
+		s.reporter.progress(position, known, paused)
+		return nil
+	}
+}
+
+func (s *playbackSession) reportStopped() tea.Cmd {
+	position, known := s.position, s.positionKnown
+	return func() tea.Msg {
+		// Losing the final report loses the resume position, which is worth
+		// a warning; playback itself went fine.
+		if err := s.reporter.stop(position, known); err != nil && !errors.Is(err, jellyfin.ErrSessionEnded) {
+			return notifyMsg{severity: severityWarning, message: "Could not save the playback position: " + err.Error()}
+		}
+		return nil
+	}
+}
+
+// stopNow returns a function for a command to stop s and report it stopped
+// right away, rather than when its EndEvent arrives, because the client is
+// about to change users and the report would be sent as the next one. It
+// returns nil for a nil session.
+func (s *playbackSession) stopNow() func() {
+	if s == nil {
+		return nil
+	}
+	position, known := s.position, s.positionKnown
+	return func() {
+		s.player.Stop()
+		s.reporter.stop(position, known)
+	}
+}
+
+// abandon stops s without reporting anything more, for when someone else
+// has logged in since the session expired and reports would be sent as them.
+func (s *playbackSession) abandon() tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		s.reporter.abandon()
+		s.player.Stop()
+		return nil
+	}
+}
+
+// controllable reports whether the player supports more than stopping.
+func (s *playbackSession) controllable() bool {
+	_, ok := s.player.(player.Controller)
+	return ok
+}
+
+func (s *playbackSession) control(control playerControl) tea.Cmd {
+	return func() tea.Msg {
+		if control == controlStop {
+			if err := s.player.Stop(); err != nil {
+				return errorMsg{err}
+			}
+			return nil
+		}
+
+		controller, ok := s.player.(player.Controller)
+		if !ok {
+			return nil
+		}
+
+		var err error
+		switch control {
+		case controlTogglePause:
+			err = controller.TogglePause()
+		case controlSeekBackward:
+			err = controller.Seek(-seekStep)
+		case controlSeekForward:
+			err = controller.Seek(seekStep)
+		case controlVolumeDown:
+			err = controller.AdjustVolume(-volumeStep)
+		case controlVolumeUp:
+			err = controller.AdjustVolume(volumeStep)
+		}
+		if err != nil {
+			return errorMsg{err}
+		}
+		return nil
+	}
+}
+
+// updatePlayback handles the messages that drive the active playback
+// session. It reports whether msg was one of them.
+func (m Model) updatePlayback(msg tea.Msg) (Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case playItemMsg:
+		// Playing replaces whatever is playing, and the queue with it; the
+		// old session still drains its events and reports its stop.
+		m.queue.Replace([]jellyfin.MediaItem{msg.item}, 0)
+		return m, m.startPlayback(msg.item, msg.startAt, msg.tracks), true
+
+	case playQueueMsg:
+		m.queue.Replace(msg.items, msg.index)
+		return m, m.playCurrent(), true
+
+	case playQueueIndexMsg:
+		if _, ok := m.queue.Jump(msg.index); !ok {
+			return m, nil, true
+		}
+		return m, m.playCurrent(), true
+
+	case enqueueMsg:
+		start := m.queue.Len()
+		m.queue.Add(msg.items...)
+		if len(msg.items) == 0 {
+			return m, nil, true
+		}
+		if m.playback != nil {
+			var cmd tea.Cmd
+			m, cmd = m.notify(severitySuccess, fmt.Sprintf("Added %d to the queue", len(msg.items)))
+			return m, cmd, true
+		}
+		m.queue.Jump(start)
+		return m, m.playCurrent(), true
+
+	case playbackStartedMsg:
+		m.playback = msg.session
+		m.queueModel.playing = true
+		m.nowPlaying = newNowPlayingModel(msg.session.item, msg.session.position, msg.session.controllable(), m.queue)
+		m = m.open(nowPlayingView)
+		return m, tea.Batch(msg.session.waitForEvent(), msg.session.scheduleProgressReport()), true
+
+	case playerEventMsg:
+		session := msg.session
+		if session == m.playback {
+			m.nowPlaying, _ = m.nowPlaying.Update(msg)
+		}
+
+		switch event := msg.event.(type) {
+		case player.PositionEvent:
+			session.position = event.Position
+			session.positionKnown = true
+		case player.PauseEvent:
+			session.paused = event.Paused
+			return m, tea.Batch(session.reportProgress(), session.waitForEvent()), true
+		case player.EndEvent:
+			if runtime := session.item.Runtime(); event.Finished && runtime > 0 {
+				session.position = runtime
+				session.positionKnown = true
+			}
+			finished := func() tea.Msg {
+				if event.Err != nil && !event.Finished {
+					return errorMsg{errors.Wrap(errors.KindAPI, "Playback failed", event.Err)}
+				}
+				return playbackFinishedMsg{item: session.item}
+			}
+			cmds := []tea.Cmd{session.reportStopped(), finished}
+
+			if session == m.playback {
+				m.playback = nil
+				m.queueModel.playing = false
+				if event.Finished {
+					if next, ok := m.queue.Advance(); ok {
+						var notifyCmd tea.Cmd
+						m, notifyCmd = m.notify(severityInfo, "Up next: "+next.Name)
+						cmds = append(cmds, m.playCurrent(), notifyCmd)
+					}
+				}
+			}
+			return m, tea.Batch(cmds...), true
+		}
+		return m, session.waitForEvent(), true
+
+	case playbackProgressTickMsg:
+		if msg.session != m.playback {
+			return m, nil, true
+		}
+		return m, tea.Batch(msg.session.reportProgress(), msg.session.scheduleProgressReport()), true
+
+	case playerControlMsg:
+		return m, m.control(msg.control), true
+
+	case showQueueMsg:
+		m = m.open(queueView)
+		m.queueModel = newQueueModel(m.queue, m.playback != nil)
+		return m, nil, true
+	}
+
+	return m, nil, false
+}
+
+// control applies a playback shortcut: queue navigation is handled here,
+// everything else is passed on to the player.
+func (m Model) control(control playerControl) tea.Cmd {
+	switch control {
+	case controlNext:
+		if _, ok := m.queue.Next(); ok {
+			return m.playCurrent()
+		}
+		return nil
+	case controlPrevious:
+		if m.playback != nil && m.playback.position > restartThreshold {
+			return m.playCurrent()
+		}
+		if _, ok := m.queue.Previous(); ok {
+			return m.playCurrent()
+		}
+		return nil
+	}
+
+	if m.playback == nil {
+		return nil
+	}
+	return m.playback.control(control)
+}
+
+// playCurrent plays the queue's current entry from the beginning.
+func (m Model) playCurrent() tea.Cmd {
+	item, ok := m.queue.Current()
+	if !ok {
+		return nil
+	}
+	return m.startPlayback(item, 0, jellyfin.TrackSelection{})
+}
+
+// startPlayback plays item with the tracks chosen for it, falling back to
+// the user's language preferences for anything not chosen.
+func (m Model) startPlayback(item jellyfin.MediaItem, startAt time.Duration, tracks jellyfin.TrackSelection) tea.Cmd {
+	languages := m.config.Languages[m.client.UserID()]
+	opts := jellyfin.StreamOptions{
+		MaxBitrate:       m.config.MaxStreamingBitrate,
+		MaxHeight:        m.config.MaxResolution,
+		Tracks:           tracks,
+		AudioLanguage:    languages.Audio,
+		SubtitleLanguage: languages.Subtitle,
+	}
+	return startPlaybackCmd(m.player, m.client, item, startAt, opts)
+}
+
+// playerKeys are the playback shortcuts available from every view.
+var playerKeys = map[string]playerControl{
+	"P": controlTogglePause,
+	"<": controlSeekBackward,
+	">": controlSeekForward,
+	"-": controlVolumeDown,
+	"+": controlVolumeUp,
+	"X": controlStop,
+	"]": controlNext,
+	"[": controlPrevious,
+}
+
+// playbackReporter tells the server what is being played, so that items show
+// up as played, in Continue Watching and in the dashboard's active sessions.
+// Reporting is best effort: a failed report never interrupts playback.
+type playbackReporter struct {
+	client *jellyfin.Client
+	item   jellyfin.MediaItem
+
+	mu     sync.Mutex
+	info   jellyfin.PlaybackProgressInfo
+	played bool
+	// stopped is set once the stop is reported, or reporting is abandoned;
+	// nothing is reported after it.
+	stopped bool
+}
+
+func newPlaybackReporter(client *jellyfin.Client, item jellyfin.MediaItem, stream jellyfin.Stream) *playbackReporter {
+	playSessionID := stream.PlaySessionID
+	if playSessionID == "" {
+		playSessionID = newPlaySessionID()
+	}
+
+	return &playbackReporter{
+		client: client,
+		item:   item,
+		info: jellyfin.PlaybackProgressInfo{
+			ItemID:        item.ID,
+			MediaSourceID: stream.MediaSourceID,
+			PlaySessionID: playSessionID,
+			PlayMethod:    stream.PlayMethod,
+			CanSeek:       true,
+		},
+	}
+}
+
+func (r *playbackReporter) start(position time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.setPosition(position, true)
+	r.client.ReportPlaybackStart(r.info)
+}
+
+func (r *playbackReporter) progress(position time.Duration, known, paused bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return
+	}
+	r.setPosition(position, known)
+	r.info.IsPaused = paused
+	r.client.ReportPlaybackProgress(r.info)
+	if known {
+		r.markPlayedIfWatched(position)
+	}
+}
+
+func (r *playbackReporter) stop(position time.Duration, known bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return nil
+	}
+	r.stopped = true
+	r.setPosition(position, known)
+	err := r.client.ReportPlaybackStopped(r.info)
+	if known {
+		r.markPlayedIfWatched(position)
+	}
+	return err
+}
+
+func (r *playbackReporter) abandon() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+}
+
+// markPlayedIfWatched marks the item played once, as soon as the watched
+// threshold is crossed, so skipping the credits still counts.
+func (r *playbackReporter) markPlayedIfWatched(position time.Duration) {
+	runtime := r.item.Runtime()
+	if r.played || runtime <= 0 || float64(position) < watchedThreshold*float64(runtime) {
+		return
+	}
+	if r.client.MarkPlayed(r.item.ID) == nil {
+		r.played = true
+	}
+}
+
+// setPosition sets the position to report, or leaves it out when it is not
+// known.
+func (r *playbackReporter) setPosition(position time.Duration, known bool) {
+	if !known {
+		r.info.PositionTicks = nil
+		return
+	}
+	if runtime := r.item.Runtime(); runtime > 0 && position > runtime {
+		position = runtime
+	}
+	ticks := int64(position / 100)
+	r.info.PositionTicks = &ticks
+}
+
+func newPlaySessionID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+type playItemMsg struct {
+	item    jellyfin.MediaItem
+	startAt time.Duration
+	tracks  jellyfin.TrackSelection
+}
+
+// playQueueMsg replaces the queue with items and plays items[index].
+type playQueueMsg struct {
+	items []jellyfin.MediaItem
+	index int
+}
+
+type playbackStartedMsg struct {
+	session *playbackSession
+}
+
+type playerEventMsg struct {
+	session *playbackSession
+	event   interface{}
+}
+
+type playbackProgressTickMsg struct {
+	session *playbackSession
+}
+
+type playerControlMsg struct {
+	control playerControl
+}
+
+type playbackFinishedMsg struct {
+	item jellyfin.MediaItem
+}
@@ -21,6 +21,9 @@ var (
 	playlistSelectedStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#7D56F4")).
 				Background(lipgloss.Color("#FAFAFA"))
+
+	playlistDimStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("240"))
 )
 
 type playlistModel struct {
@@ -30,9 +33,10 @@ type playlistModel struct {
 	client       *jellyfin.Client
 }
 
-func newPlaylistModel(client *jellyfin.Client) playlistModel {
+func newPlaylistModel(client *jellyfin.Client, itemID string) playlistModel {
 	return playlistModel{
-		client: client,
+		client:       client,
+		selectedItem: itemID,
 	}
 }
 
@@ -58,11 +62,16 @@ func (m playlistModel) Update(msg tea.Msg) (playlistModel, tea.Cmd) {
 			}
 		case "n":
 			return m, m.createNewPlaylist
-		case "esc", "q":
+		case "esc":
 			return m, m.back
 		}
 	case playlistsMsg:
 		m.playlists = msg.playlists
+		if m.cursor >= len(m.playlists) {
+			m.cursor = 0
+		}
+	case playlistCreatedMsg:
+		return m, m.fetchPlaylists
 	}
 	return m, nil
 }
@@ -73,6 +82,11 @@ func (m playlistModel) View() string {
 	b.WriteString(playlistTitleStyle.Render("Playlists"))
 	b.WriteString("\n\n")
 
+	if len(m.playlists) == 0 {
+		b.WriteString(playlistDimStyle.Render("No playlists yet. Press 'n' to create one."))
+		b.WriteString("\n")
+	}
+
 	for i, playlist := range m.playlists {
 		if i == m.cursor {
 			b.WriteString(playlistSelectedStyle.Render("> " + playlist.Name))
@@ -109,14 +123,12 @@ func (m playlistModel) addToPlaylist() tea.Msg {
 	return nil
 }
 
-func (m playlistModel) createNewPlaylist() tea.Cmd {
-	return func() tea.Msg {
-		return showCreatePlaylistMsg{}
-	}
+func (m playlistModel) createNewPlaylist() tea.Msg {
+	return showCreatePlaylistMsg{}
 }
 
 func (m playlistModel) back() tea.Msg {
-	return showBrowseMsg{}
+	return backMsg{}
 }
 
 type playlistsMsg struct {
@@ -128,3 +140,80 @@ type playlistUpdateMsg struct {
 }
 
 type showCreatePlaylistMsg struct{}
+
+// createPlaylistModel asks for the name of a new playlist.
+type createPlaylistModel struct {
+	client *jellyfin.Client
+	name   string
+	err    error
+}
+
+func newCreatePlaylistModel(client *jellyfin.Client) createPlaylistModel {
+	return createPlaylistModel{
+		client: client,
+	}
+}
+
+func (m createPlaylistModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m createPlaylistModel) Update(msg tea.Msg) (createPlaylistModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if strings.TrimSpace(m.name) != "" {
+				return m, m.create
+			}
+		case "esc":
+			return m, m.back
+		case "backspace":
+			if len(m.name) > 0 {
+				m.name = m.name[:len(m.name)-1]
+			}
+		default:
+			m.name += msg.String()
+		}
+	case playlistCreateErrorMsg:
+		m.err = msg.err
+	}
+	return m, nil
+}
+
+func (m createPlaylistModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(playlistTitleStyle.Render("New Playlist"))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("Name: %s", m.name))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(playlistDimStyle.Render(m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString("Press Enter to create, Esc to cancel")
+
+	return b.String()
+}
+
+func (m createPlaylistModel) create() tea.Msg {
+	if err := m.client.CreatePlaylist(strings.TrimSpace(m.name)); err != nil {
+		return playlistCreateErrorMsg{err}
+	}
+	return playlistCreatedMsg{}
+}
+
+func (m createPlaylistModel) back() tea.Msg {
+	return backMsg{}
+}
+
+type playlistCreatedMsg struct{}
+
+type playlistCreateErrorMsg struct {
+	err error
+}
@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/queue"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	queueTitleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#7D56F4")).
+			Padding(0, 1)
+
+	queueItemStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA"))
+
+	queueCurrentStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("205")).
+				Bold(true)
+
+	queueSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				Background(lipgloss.Color("#FAFAFA"))
+
+	queueDimStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+)
+
+// queueModel lists the play queue and edits it in place. The queue itself is
+// shared with Model, which plays from it.
+type queueModel struct {
+	queue  *queue.Queue
+	cursor int
+	status string
+	// playing is set while the current entry plays, which keeps it from
+	// being removed.
+	playing bool
+}
+
+func newQueueModel(q *queue.Queue, playing bool) queueModel {
+	cursor := q.Index()
+	if cursor < 0 {
+		cursor = 0
+	}
+	return queueModel{
+		queue:   q,
+		cursor:  cursor,
+		playing: playing,
+	}
+}
+
+func (m queueModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m queueModel) Update(msg tea.Msg) (queueModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.status = ""
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < m.queue.Len()-1 {
+				m.cursor++
+			}
+		case "K":
+			if m.queue.Move(m.cursor, -1) {
+				m.cursor--
+			}
+		case "J":
+			if m.queue.Move(m.cursor, 1) {
+				m.cursor++
+			}
+		case "enter":
+			if m.queue.Len() > 0 {
+				index := m.cursor
+				return m, func() tea.Msg { return playQueueIndexMsg{index: index} }
+			}
+		case "d", "delete":
+			if m.playing && m.cursor == m.queue.Index() {
+				m.status = "The entry that is playing can't be removed; skip it first"
+				return m, nil
+			}
+			if !m.queue.Remove(m.cursor) {
+				return m, nil
+			}
+			if m.cursor >= m.queue.Len() && m.cursor > 0 {
+				m.cursor--
+			}
+		case "c":
+			m.queue.Clear()
+			m.cursor = 0
+		case "z":
+			m.queue.ToggleShuffle()
+		case "r":
+			m.queue.CycleRepeat()
+		case "esc":
+			return m, m.back
+		}
+	}
+	return m, nil
+}
+
+func (m queueModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(queueTitleStyle.Render(fmt.Sprintf("Queue (%d)", m.queue.Len())))
+	b.WriteString(queueDimStyle.Render(m.modes()))
+	b.WriteString("\n\n")
+
+	if m.queue.Len() == 0 {
+		b.WriteString(queueDimStyle.Render("The queue is empty. Press 'a' on an item, season, album or playlist to add it."))
+		b.WriteString("\n")
+	}
+
+	for i, entry := range m.queue.Entries() {
+		marker := "  "
+		if i == m.queue.Index() {
+			marker = "▶ "
+		}
+		line := fmt.Sprintf("%s%d. %s", marker, i+1, queueEntryName(entry.Item))
+		if entry.Item.RunTimeTicks > 0 {
+			line += "  " + formatDuration(entry.Item.Runtime())
+		}
+
+		switch {
+		case i == m.cursor:
+			b.WriteString(queueSelectedStyle.Render(line))
+		case i == m.queue.Index():
+			b.WriteString(queueCurrentStyle.Render(line))
+		default:
+			b.WriteString(queueItemStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+
+	if m.status != "" {
+		b.WriteString("\n" + queueDimStyle.Render(m.status) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString("Press Enter to play, 'd' to remove, Shift+J/K to move, 'c' to clear\n")
+	b.WriteString("Press 'z' to toggle shuffle, 'r' to change repeat\n")
+	b.WriteString("Press 'q' or Esc to go back")
+
+	return b.String()
+}
+
+func (m queueModel) modes() string {
+	modes := "  repeat " + m.queue.Repeat().String()
+	if m.queue.Shuffle() {
+		modes += ", shuffle"
+	}
+	return modes
+}
+
+// queueEntryName names an entry so it can be told apart from other entries,
+// e.g. episodes of different series with the same title.
+func queueEntryName(item jellyfin.MediaItem) string {
+	switch {
+	case item.Type == "Episode":
+		return fmt.Sprintf("%s %s %s", item.SeriesName, episodeLabel(item), item.Name)
+	case item.MediaType == "Audio" && item.AlbumArtist != "":
+		return item.AlbumArtist + " - " + item.Name
+	default:
+		return item.Name
+	}
+}
+
+// enqueueCmd resolves item to the playable items it stands for and adds them
+// to the queue: a season or series becomes its episodes, an album its tracks
+// and a playlist its entries.
+func enqueueCmd(client *jellyfin.Client, item jellyfin.MediaItem) tea.Cmd {
+	return func() tea.Msg {
+		var items []jellyfin.MediaItem
+		var err error
+
+		switch item.Type {
+		case "Series":
+			items, err = client.GetEpisodes(item.ID, "")
+		case "Season":
+			items, err = client.GetEpisodes(item.SeriesID, item.ID)
+		case "MusicAlbum":
+			items, err = client.GetTracks(item.ID)
+		case "Playlist":
+			items, err = client.GetPlaylistItems(item.ID)
+		default:
+			if item.IsFolder {
+				return errorMsg{errors.NewInputError(fmt.Sprintf("%s can't be added to the queue", item.Name))}
+			}
+			items = []jellyfin.MediaItem{item}
+		}
+		if err != nil {
+			return errorMsg{err}
+		}
+
+		return enqueueMsg{items: items}
+	}
+}
+
+func (m queueModel) back() tea.Msg {
+	return backMsg{}
+}
+
+type enqueueMsg struct {
+	items []jellyfin.MediaItem
+}
+
+type playQueueIndexMsg struct {
+	index int
+}
+
+type showQueueMsg struct{}
@@ -0,0 +1,160 @@
+package ui
+
+// viewID identifies one of the UI's views.
+type viewID int
+
+const (
+	loginView viewID = iota
+	homeView
+	browseView
+	filterView
+	detailView
+	searchView
+	playlistView
+	createPlaylistView
+	settingsView
+	helpView
+	usersView
+	seriesView
+	musicView
+	nowPlayingView
+	queueView
+	notificationsView
+)
+
+// route is one entry of the navigation stack.
+type route struct {
+	view viewID
+	// saved holds the view's model while the same view is open again
+	// higher up the stack, e.g. a detail view opened from a search that was
+	// itself opened from a detail view. It is restored when this entry is
+	// back on top.
+	saved interface{}
+}
+
+// router is the navigation stack. The top entry is the active view and the
+// bottom one, the root, is never popped. Each view's model lives on in Model
+// while it is buried, so going back finds it as it was left.
+type router struct {
+	stack []route
+}
+
+func newRouter(root viewID) router {
+	return router{stack: []route{{view: root}}}
+}
+
+func (r router) current() viewID {
+	return r.stack[len(r.stack)-1].view
+}
+
+// contains reports whether view is anywhere on the stack.
+func (r router) contains(view viewID) bool {
+	return r.index(view) >= 0
+}
+
+// index returns the position of the topmost entry for view, or -1.
+func (r router) index(view viewID) int {
+	for i := len(r.stack) - 1; i >= 0; i-- {
+		if r.stack[i].view == view {
+			return i
+		}
+	}
+	return -1
+}
+
+// push opens view on top of the stack. Opening the active view again is a
+// no-op. If the view is already open further down, live is the model it has
+// there, which is saved so popping back restores it.
+func (r router) push(view viewID, live interface{}) router {
+	if r.current() == view {
+		return r
+	}
+
+	stack := make([]route, len(r.stack), len(r.stack)+1)
+	copy(stack, r.stack)
+	if i := r.index(view); i >= 0 {
+		stack[i].saved = live
+	}
+	r.stack = append(stack, route{view: view})
+	return r
+}
+
+// pop closes the active view and returns the entry now on top. At the root
+// it does nothing and reports false.
+func (r router) pop() (router, route, bool) {
+	if len(r.stack) == 1 {
+		return r, r.stack[0], false
+	}
+
+	stack := make([]route, len(r.stack)-1)
+	copy(stack, r.stack)
+	top := stack[len(stack)-1]
+	stack[len(stack)-1].saved = nil
+	r.stack = stack
+	return r, top, true
+}
+
+// open makes view the active view. Views that show one item of many, like
+// the detail view, stack: opening one again saves the model it had so going
+// back restores it. Other views exist once, so opening one that is already
+// on the stack goes back to it.
+func (m Model) open(view viewID) Model {
+	live := m.viewModel(view)
+	if live == nil && m.router.contains(view) {
+		return m.backTo(view)
+	}
+	m.router = m.router.push(view, live)
+	return m
+}
+
+// back closes the active view. At the root it does nothing.
+func (m Model) back() Model {
+	router, top, ok := m.router.pop()
+	if !ok {
+		return m
+	}
+	m.router = router
+	if top.saved != nil {
+		m = m.restore(top.view, top.saved)
+	}
+	return m
+}
+
+// backTo closes views until view is active.
+func (m Model) backTo(view viewID) Model {
+	for m.router.current() != view && len(m.router.stack) > 1 {
+		m = m.back()
+	}
+	return m
+}
+
+// viewModel returns the model of a stacking view, for saving; nil means the
+// view doesn't stack.
+func (m Model) viewModel(view viewID) interface{} {
+	switch view {
+	case detailView:
+		return m.detailModel
+	case seriesView:
+		return m.seriesModel
+	case musicView:
+		return m.musicModel
+	case playlistView:
+		return m.playlistModel
+	default:
+		return nil
+	}
+}
+
+func (m Model) restore(view viewID, saved interface{}) Model {
+	switch view {
+	case detailView:
+		m.detailModel = saved.(detailModel)
+	case seriesView:
+		m.seriesModel = saved.(seriesModel)
+	case musicView:
+		m.musicModel = saved.(musicModel)
+	case playlistView:
+		m.playlistModel = saved.(playlistModel)
+	}
+	return m
+}
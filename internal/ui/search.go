@@ -50,15 +50,16 @@ func (m searchModel) Update(msg tea.Msg) (searchModel, tea.Cmd) {
 			} else if len(m.results) > 0 {
 				return m, m.selectItem
 			}
-		case "up", "k":
+		// Letters belong to the query, so only the arrows move the cursor.
+		case "up":
 			if m.cursor > 0 {
 				m.cursor--
 			}
-		case "down", "j":
+		case "down":
 			if m.cursor < len(m.results)-1 {
 				m.cursor++
 			}
-		case "esc", "q":
+		case "esc":
 			return m, m.back
 		case "backspace":
 			if len(m.query) > 0 {
@@ -96,7 +97,7 @@ func (m searchModel) View() string {
 
 	b.WriteString("\n")
 	b.WriteString("Press Enter to search or select item\n")
-	b.WriteString("Press 'q' or Esc to go back")
+	b.WriteString("Press Esc to go back")
 
 	return b.String()
 }
@@ -117,7 +118,7 @@ func (m searchModel) selectItem() tea.Msg {
 }
 
 func (m searchModel) back() tea.Msg {
-	return showBrowseMsg{}
+	return backMsg{}
 }
 
 type searchResultMsg struct {
@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	seriesTitleStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				Background(lipgloss.Color("#7D56F4")).
+				Padding(0, 1)
+
+	seriesItemStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA"))
+
+	seriesSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				Background(lipgloss.Color("#FAFAFA"))
+
+	seriesDimStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+)
+
+// seriesModel shows a series' seasons and, once one is opened, its episodes.
+type seriesModel struct {
+	client       *jellyfin.Client
+	series       jellyfin.MediaItem
+	seasons      []jellyfin.MediaItem
+	season       *jellyfin.MediaItem
+	episodes     []jellyfin.MediaItem
+	cursor       int
+	seasonCursor int
+	status       string
+}
+
+func newSeriesModel(client *jellyfin.Client, series jellyfin.MediaItem) seriesModel {
+	return seriesModel{
+		client: client,
+		series: series,
+	}
+}
+
+func (m seriesModel) Init() tea.Cmd {
+	return m.fetchSeasons
+}
+
+func (m seriesModel) Update(msg tea.Msg) (seriesModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < m.listLen()-1 {
+				m.cursor++
+			}
+		case "enter":
+			if m.season == nil && len(m.seasons) > 0 {
+				season := m.seasons[m.cursor]
+				m.season = &season
+				m.seasonCursor = m.cursor
+				m.cursor = 0
+				m.episodes = nil
+				return m, m.fetchEpisodes(season.ID)
+			}
+			if m.season != nil && len(m.episodes) > 0 {
+				episode := m.episodes[m.cursor]
+				return m, func() tea.Msg { return showDetailMsg{item: episode} }
+			}
+		case "a":
+			if m.season == nil && len(m.seasons) > 0 {
+				return m, enqueueCmd(m.client, m.seasons[m.cursor])
+			}
+			if m.season != nil && len(m.episodes) > 0 {
+				return m, enqueueCmd(m.client, m.episodes[m.cursor])
+			}
+		case "n":
+			m.status = "Finding next unwatched episode..."
+			return m, m.findNextUnwatched
+		case "backspace", "esc":
+			if m.season != nil {
+				m.season = nil
+				m.cursor = m.seasonCursor
+				return m, nil
+			}
+			return m, m.back
+		}
+	case seasonsMsg:
+		m.seasons = msg.seasons
+	case episodesMsg:
+		if m.season != nil && m.season.ID == msg.seasonID {
+			m.episodes = msg.episodes
+		}
+	case nextUnwatchedMsg:
+		if msg.episode == nil {
+			m.status = "All episodes have been watched"
+			return m, nil
+		}
+		m.status = "Playing " + episodeLabel(*msg.episode)
+		episode := *msg.episode
+		return m, func() tea.Msg {
+			return playItemMsg{item: episode, startAt: episode.UserData.PlaybackPosition()}
+		}
+	}
+	return m, nil
+}
+
+func (m seriesModel) listLen() int {
+	if m.season != nil {
+		return len(m.episodes)
+	}
+	return len(m.seasons)
+}
+
+func (m seriesModel) View() string {
+	var b strings.Builder
+
+	title := m.series.Name
+	if m.season != nil {
+		title += " > " + m.season.Name
+	}
+	b.WriteString(seriesTitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.season == nil {
+		for i, season := range m.seasons {
+			line := season.Name
+			if season.UserData.Played {
+				line += " ✓"
+			} else if season.UserData.UnplayedItemCount > 0 {
+				line += seriesDimStyle.Render(fmt.Sprintf(" (%d unwatched)", season.UserData.UnplayedItemCount))
+			}
+			b.WriteString(m.renderLine(i, line))
+		}
+	} else {
+		for i, episode := range m.episodes {
+			b.WriteString(m.renderLine(i, episodeLine(episode)))
+		}
+	}
+
+	if m.status != "" {
+		b.WriteString("\n" + seriesDimStyle.Render(m.status) + "\n")
+	}
+
+	b.WriteString("\n")
+	if m.season == nil {
+		b.WriteString("Press Enter to open a season\n")
+	} else {
+		b.WriteString("Press Enter to view an episode, Backspace for seasons\n")
+	}
+	b.WriteString("Press 'n' to play the next unwatched episode, 'a' to add to the queue\n")
+	b.WriteString("Press 'q' or Esc to go back")
+
+	return b.String()
+}
+
+func (m seriesModel) renderLine(i int, line string) string {
+	if i == m.cursor {
+		return seriesSelectedStyle.Render("> "+line) + "\n"
+	}
+	return seriesItemStyle.Render("  "+line) + "\n"
+}
+
+func episodeLine(episode jellyfin.MediaItem) string {
+	marker := "•"
+	if episode.UserData.Played {
+		marker = "✓"
+	}
+
+	line := fmt.Sprintf("%s %s %s", marker, episodeLabel(episode), episode.Name)
+	if date := formatDate(episode.PremiereDate); date != "" {
+		line += seriesDimStyle.Render("  " + date)
+	}
+	return line
+}
+
+// episodeLabel formats an episode's season and episode numbers as S01E02.
+func episodeLabel(episode jellyfin.MediaItem) string {
+	return fmt.Sprintf("S%02dE%02d", episode.ParentIndexNumber, episode.IndexNumber)
+}
+
+// formatDate trims one of Jellyfin's ISO 8601 timestamps to its date.
+func formatDate(timestamp string) string {
+	if len(timestamp) < len("2006-01-02") {
+		return timestamp
+	}
+	return timestamp[:len("2006-01-02")]
+}
+
+func (m seriesModel) fetchSeasons() tea.Msg {
+	seasons, err := m.client.GetSeasons(m.series.ID)
+	if err != nil {
+		return errorMsg{err}
+	}
+	return seasonsMsg{seasons: seasons}
+}
+
+func (m seriesModel) fetchEpisodes(seasonID string) tea.Cmd {
+	return func() tea.Msg {
+		episodes, err := m.client.GetEpisodes(m.series.ID, seasonID)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return episodesMsg{seasonID: seasonID, episodes: episodes}
+	}
+}
+
+// findNextUnwatched asks the server what to watch next, and otherwise picks
+// the first unplayed episode, leaving out specials and episodes that have no
+// file.
+func (m seriesModel) findNextUnwatched() tea.Msg {
+	next, err := m.client.GetNextEpisode(m.series.ID)
+	if err != nil {
+		return errorMsg{err}
+	}
+	if next != nil {
+		return nextUnwatchedMsg{episode: next}
+	}
+
+	episodes, err := m.client.GetEpisodes(m.series.ID, "")
+	if err != nil {
+		return errorMsg{err}
+	}
+	for _, episode := range episodes {
+		if episode.UserData.Played || episode.ParentIndexNumber == 0 || episode.LocationType == "Virtual" {
+			continue
+		}
+		episode := episode
+		return nextUnwatchedMsg{episode: &episode}
+	}
+	return nextUnwatchedMsg{}
+}
+
+func (m seriesModel) back() tea.Msg {
+	return backMsg{}
+}
+
+type seasonsMsg struct {
+	seasons []jellyfin.MediaItem
+}
+
+type episodesMsg struct {
+	seasonID string
+	episodes []jellyfin.MediaItem
+}
+
+type nextUnwatchedMsg struct {
+	episode *jellyfin.MediaItem
+}
+
+type showSeriesMsg struct {
+	series jellyfin.MediaItem
+}
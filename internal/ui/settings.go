@@ -2,9 +2,11 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/TheWanderingShinobi/jellyfin-tui/internal/config"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -27,11 +29,13 @@ type settingsModel struct {
 	cursor  int
 	options []string
 	config  *config.Config
+	editing *editSettingModel
+	err     error
 }
 
 func newSettingsModel(cfg *config.Config) settingsModel {
 	return settingsModel{
-		options: []string{"Server URL", "Default User", "Items Per Page"},
+		options: []string{"Server URL", "Default User", "Items Per Page", "Max Bitrate (Mbps)", "Max Resolution"},
 		config:  cfg,
 	}
 }
@@ -43,6 +47,11 @@ func (m settingsModel) Init() tea.Cmd {
 func (m settingsModel) Update(msg tea.Msg) (settingsModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.editing != nil {
+			editor, cmd := m.editing.Update(msg)
+			m.editing = &editor
+			return m, cmd
+		}
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
@@ -53,15 +62,31 @@ func (m settingsModel) Update(msg tea.Msg) (settingsModel, tea.Cmd) {
 				m.cursor++
 			}
 		case "enter":
-			return m, m.editSetting
-		case "esc", "q":
+			editor := newEditSettingModel(m.options[m.cursor], m.editValue(m.cursor))
+			m.editing = &editor
+			m.err = nil
+		case "esc":
 			return m, m.back
 		}
+	case showSettingsMsg:
+		m.editing = nil
+	case settingsUpdateMsg:
+		m.editing = nil
+		if err := m.apply(msg.setting, msg.value); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.err = nil
+		return m, m.save()
 	}
 	return m, nil
 }
 
 func (m settingsModel) View() string {
+	if m.editing != nil {
+		return m.editing.View()
+	}
+
 	var b strings.Builder
 
 	b.WriteString(settingsTitleStyle.Render("Settings"))
@@ -78,6 +103,12 @@ func (m settingsModel) View() string {
 		b.WriteString("\n")
 	}
 
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errors.ErrorStyle.Render(m.err.Error()))
+		b.WriteString("\n")
+	}
+
 	b.WriteString("\n")
 	b.WriteString("Press Enter to edit a setting\n")
 	b.WriteString("Press 'q' or Esc to go back")
@@ -93,21 +124,97 @@ func (m settingsModel) getSettingValue(index int) string {
 		return m.config.DefaultUser
 	case 2:
 		return fmt.Sprintf("%d", m.config.ItemsPerPage)
+	case 3:
+		if m.config.MaxStreamingBitrate == 0 {
+			return "Unlimited"
+		}
+		return strconv.FormatFloat(float64(m.config.MaxStreamingBitrate)/1e6, 'f', -1, 64) + " Mbps"
+	case 4:
+		if m.config.MaxResolution == 0 {
+			return "Unlimited"
+		}
+		return fmt.Sprintf("%dp", m.config.MaxResolution)
 	default:
 		return ""
 	}
 }
 
-func (m settingsModel) editSetting() tea.Msg {
-	return editSettingMsg{setting: m.options[m.cursor]}
+// editValue is the value the editor starts from, without the units and
+// "Unlimited" placeholders the list shows.
+func (m settingsModel) editValue(index int) string {
+	switch index {
+	case 3:
+		if m.config.MaxStreamingBitrate == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(float64(m.config.MaxStreamingBitrate)/1e6, 'f', -1, 64)
+	case 4:
+		if m.config.MaxResolution == 0 {
+			return ""
+		}
+		return strconv.Itoa(m.config.MaxResolution)
+	default:
+		return m.getSettingValue(index)
+	}
+}
+
+// apply validates value and stores it in the config. An empty limit means
+// unlimited.
+func (m settingsModel) apply(setting, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch setting {
+	case "Server URL":
+		if value == "" {
+			return errors.NewInputError("Server URL cannot be empty")
+		}
+		m.config.ServerURL = strings.TrimRight(value, "/")
+	case "Default User":
+		m.config.DefaultUser = value
+	case "Items Per Page":
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return errors.NewInputError("Items per page must be a positive number")
+		}
+		m.config.ItemsPerPage = n
+	case "Max Bitrate (Mbps)":
+		if value == "" || value == "0" {
+			m.config.MaxStreamingBitrate = 0
+			return nil
+		}
+		mbps, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(value), "mbps"), 64)
+		if err != nil || mbps <= 0 {
+			return errors.NewInputError("Max bitrate must be a number of Mbps, e.g. 8 or 1.5")
+		}
+		m.config.MaxStreamingBitrate = int64(mbps * 1e6)
+	case "Max Resolution":
+		if value == "" || value == "0" {
+			m.config.MaxResolution = 0
+			return nil
+		}
+		height, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(value), "p"))
+		if err != nil || height <= 0 {
+			return errors.NewInputError("Max resolution must be a video height, e.g. 720 or 1080")
+		}
+		m.config.MaxResolution = height
+	}
+	return nil
 }
 
-func (m settingsModel) back() tea.Msg {
-	return showBrowseMsg{}
+// save writes a copy of the config, since the command runs while Update may
+// change it.
+func (m settingsModel) save() tea.Cmd {
+	cfg := m.config.Copy()
+	return func() tea.Msg {
+		if err := config.Save(cfg); err != nil {
+			return errorMsg{err}
+		}
+		return notifyMsg{severity: severitySuccess, message: "Settings saved"}
+	}
 }
 
-type editSettingMsg struct {
-	setting string
+func (m settingsModel) back() tea.Msg {
+	return backMsg{}
 }
 
 type settingsUpdateMsg struct {
@@ -115,7 +222,7 @@ type settingsUpdateMsg struct {
 	value   string
 }
 
-// This would be a separate model for editing a single setting
+// editSettingModel edits a single setting as free text.
 type editSettingModel struct {
 	setting string
 	value   string
@@ -153,7 +260,7 @@ func (m editSettingModel) Update(msg tea.Msg) (editSettingModel, tea.Cmd) {
 
 func (m editSettingModel) View() string {
 	return fmt.Sprintf(
-		"Editing %s:\n\n%s\n\nPress Enter to save, Esc to cancel",
+		"Editing %s:\n\n%s\n\nLeave limits empty for no limit\nPress Enter to save, Esc to cancel",
 		m.setting,
 		m.value,
 	)
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/errors"
+	"github.com/TheWanderingShinobi/jellyfin-tui/internal/jellyfin"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	usersTitleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#7D56F4")).
+			Padding(0, 1)
+
+	usersItemStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA"))
+
+	usersSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				Background(lipgloss.Color("#FAFAFA"))
+
+	avatarColors = []string{"#E06C75", "#98C379", "#E5C07B", "#61AFEF", "#C678DD", "#56B6C2", "#D19A66"}
+)
+
+type userPickerModel struct {
+	users     []jellyfin.User
+	cursor    int
+	client    *jellyfin.Client
+	prompting bool
+	password  string
+	switching bool
+	err       error
+}
+
+func newUserPickerModel(client *jellyfin.Client) userPickerModel {
+	return userPickerModel{
+		client: client,
+	}
+}
+
+func (m userPickerModel) Init() tea.Cmd {
+	return m.fetchUsers
+}
+
+func (m userPickerModel) Update(msg tea.Msg) (userPickerModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.switching {
+			return m, nil
+		}
+		if m.prompting {
+			return m.updatePassword(msg)
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.users)-1 {
+				m.cursor++
+			}
+		case "enter":
+			if len(m.users) == 0 {
+				return m, nil
+			}
+			m.err = nil
+			if m.users[m.cursor].HasPassword && !m.client.UsingAPIKey() {
+				m.prompting = true
+				m.password = ""
+				return m, nil
+			}
+			m.switching = true
+			return m, m.requestSwitch
+		case "esc":
+			return m, m.back
+		}
+	case usersMsg:
+		m.users = msg.users
+		m.cursor = 0
+	case userSwitchErrorMsg:
+		m.switching = false
+		m.err = msg.err
+	}
+	return m, nil
+}
+
+func (m userPickerModel) updatePassword(msg tea.KeyMsg) (userPickerModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.prompting = false
+		m.switching = true
+		return m, m.requestSwitch
+	case "esc":
+		m.prompting = false
+		m.password = ""
+	case "backspace":
+		if len(m.password) > 0 {
+			password := []rune(m.password)
+			m.password = string(password[:len(password)-1])
+		}
+	default:
+		m.password += msg.String()
+	}
+	return m, nil
+}
+
+func (m userPickerModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(usersTitleStyle.Render("Switch User"))
+	b.WriteString("\n\n")
+
+	for i, user := range m.users {
+		line := fmt.Sprintf("%s %s", avatar(user.Name), user.Name)
+		if user.ID == m.client.UserID() {
+			line += " (current)"
+		}
+		if i == m.cursor {
+			b.WriteString(usersSelectedStyle.Render("> ") + line)
+		} else {
+			b.WriteString(usersItemStyle.Render("  ") + line)
+		}
+		b.WriteString("\n")
+	}
+
+	if m.prompting {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("Password for %s: %s", m.users[m.cursor].Name, strings.Repeat("*", len([]rune(m.password)))))
+		b.WriteString("\n")
+	}
+	if m.switching {
+		b.WriteString("\n" + blurredStyle.Render("Switching user...") + "\n")
+	}
+	if m.err != nil {
+		b.WriteString("\n" + errors.FormatError(m.err) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString("Press Enter to switch to the selected user\n")
+	b.WriteString("Press 'q' or Esc to go back")
+
+	return b.String()
+}
+
+// avatar renders a user's initials on a background colour derived from their
+// name, so the same user always gets the same badge.
+func avatar(name string) string {
+	var initials []rune
+	for _, word := range strings.Fields(name) {
+		r := []rune(word)[0]
+		initials = append(initials, unicode.ToUpper(r))
+		if len(initials) == 2 {
+			break
+		}
+	}
+	if len(initials) == 0 {
+		initials = []rune{'?'}
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	color := avatarColors[h.Sum32()%uint32(len(avatarColors))]
+
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#1E1E1E")).
+		Background(lipgloss.Color(color)).
+		Bold(true).
+		Padding(0, 1).
+		Render(string(initials))
+}
+
+func (m userPickerModel) fetchUsers() tea.Msg {
+	users, err := m.client.GetPublicUsers()
+	if err != nil {
+		return errorMsg{err}
+	}
+	// Servers can hide users from the login screen; administrators can
+	// still list everyone.
+	if len(users) == 0 {
+		users, err = m.client.GetUsers()
+		if err != nil {
+			return errorMsg{err}
+		}
+	}
+	return usersMsg{users: users}
+}
+
+// requestSwitch asks Model to switch users, since whatever is playing must be
+// stopped first.
+func (m userPickerModel) requestSwitch() tea.Msg {
+	return switchUserMsg{user: m.users[m.cursor], password: m.password}
+}
+
+// switchUserCmd switches the client to user, calling stopPlayback, if not
+// nil, while the previous user is still logged in.
+func switchUserCmd(client *jellyfin.Client, user jellyfin.User, password string, stopPlayback func()) tea.Cmd {
+	return func() tea.Msg {
+		result, err := client.SwitchUser(user, password, stopPlayback)
+		if err != nil {
+			return userSwitchErrorMsg{err}
+		}
+		return userSwitchedMsg{user: result.User, token: result.AccessToken}
+	}
+}
+
+func (m userPickerModel) back() tea.Msg {
+	return backMsg{}
+}
+
+type usersMsg struct {
+	users []jellyfin.User
+}
+
+type switchUserMsg struct {
+	user     jellyfin.User
+	password string
+}
+
+type userSwitchedMsg struct {
+	user  jellyfin.User
+	token string
+}
+
+type userSwitchErrorMsg struct {
+	err error
+}
+
+type showUsersMsg struct{}